@@ -0,0 +1,170 @@
+package figo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var ErrNotCached = errors.New("figma: response not cached")
+
+// Cache saves file, nodes and variables responses on disk and serves them again
+// while the file version in Figma is unchanged.
+type Cache struct {
+	Dir     string // Folder for the cached responses, one sub folder per file key
+	Offline bool   // Only serve cached responses and never request the API
+
+	mu       sync.Mutex
+	versions map[string]cacheVersion // latest versions checked in this run, by file key
+}
+
+type cacheVersion struct {
+	Version      string `json:"version"`
+	LastModified string `json:"lastModified"`
+}
+
+type cacheEntry struct {
+	Uri string `json:"uri"`
+	cacheVersion
+}
+
+func (c *Cache) paths(fileKey string, uri string) (string, string) {
+	hash := sha256.Sum256([]byte(uri))
+	name := hex.EncodeToString(hash[:])
+	dir := filepath.Join(c.Dir, fileName(fileKey))
+
+	return filepath.Join(dir, name+".json"), filepath.Join(dir, name+".meta.json")
+}
+
+func (c *Cache) read(fileKey string, uri string) ([]byte, cacheEntry, error) {
+	var entry cacheEntry
+	bodyPath, metaPath := c.paths(fileKey, uri)
+
+	meta, err := os.ReadFile(metaPath)
+	if err != nil {
+		return nil, entry, err
+	}
+
+	if err := json.Unmarshal(meta, &entry); err != nil {
+		return nil, entry, err
+	}
+
+	body, err := os.ReadFile(bodyPath)
+	return body, entry, err
+}
+
+func (c *Cache) write(fileKey string, uri string, body []byte, version cacheVersion) error {
+	bodyPath, metaPath := c.paths(fileKey, uri)
+
+	if err := os.MkdirAll(filepath.Dir(bodyPath), 0o755); err != nil {
+		return err
+	}
+
+	meta, err := json.Marshal(cacheEntry{Uri: uri, cacheVersion: version})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(bodyPath, body, 0o644); err != nil {
+		return err
+	}
+
+	// the meta file is written last, a response without it is never served
+	return os.WriteFile(metaPath, meta, 0o644)
+}
+
+// latestVersion reads the file version with depth=1, which skips the document tree,
+// the result is kept for the lifetime of the cache.
+func (f *Figma) latestVersion(ctx context.Context) (cacheVersion, error) {
+	cache := f.Cache
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if version, ok := cache.versions[f.FILE_KEY]; ok {
+		return version, nil
+	}
+
+	var version cacheVersion
+	body, err := f.get(ctx, fmt.Sprintf("%v/v1/files/%v?depth=1", f.baseUrl(), f.FILE_KEY))
+	if err != nil {
+		return version, err
+	}
+
+	if err := json.Unmarshal(body, &version); err != nil {
+		return version, err
+	}
+
+	if cache.versions == nil {
+		cache.versions = make(map[string]cacheVersion)
+	}
+	cache.versions[f.FILE_KEY] = version
+
+	return version, nil
+}
+
+// getCached requests an endpoint through the cache, when there is one.
+// Responses for a uri with a version parameter never change and are served without checking the latest
+// version, endpoints without one, like variables, are checked against the latest version.
+func (f *Figma) getCached(ctx context.Context, uri string) ([]byte, error) {
+	if f.Cache == nil {
+		return f.get(ctx, uri)
+	}
+
+	body, entry, readError := f.Cache.read(f.FILE_KEY, uri)
+
+	if f.Cache.Offline {
+		if readError != nil {
+			return nil, fmt.Errorf("%w: %v", ErrNotCached, readError)
+		}
+		f.logger().Debug("figma response served offline", "uri", uri)
+		return body, nil
+	}
+
+	pinned := uriVersion(uri)
+
+	if readError == nil && pinned != "" {
+		f.logger().Debug("figma response served from cache", "uri", uri, "version", pinned)
+		return body, nil
+	}
+
+	version := cacheVersion{Version: pinned}
+	if pinned == "" {
+		latest, err := f.latestVersion(ctx)
+		if err != nil {
+			return nil, err
+		}
+		version = latest
+
+		if readError == nil && entry.cacheVersion == version {
+			f.logger().Debug("figma response served from cache", "uri", uri, "version", version.Version)
+			return body, nil
+		}
+	}
+
+	body, err := f.get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.Cache.write(f.FILE_KEY, uri, body, version); err != nil {
+		f.logger().Warn("figma response not cached", "uri", uri, "error", err)
+	}
+
+	return body, nil
+}
+
+func uriVersion(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return ""
+	}
+	return parsed.Query().Get("version")
+}
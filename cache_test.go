@@ -0,0 +1,136 @@
+package figo
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	requests := 0
+	lastModified := "2026-01-01T00:00:00Z"
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("depth") == "1" {
+			w.Write([]byte(`{"name":"Design System","version":"1","lastModified":"` + lastModified + `"}`))
+			return
+		}
+		w.Write([]byte(`{"name":"Design System","version":"1","lastModified":"` + lastModified + `","document":{"id":"0:0"}}`))
+	})
+
+	dir := t.TempDir()
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url, Cache: &Cache{Dir: dir}}
+	if _, err := f.GetData(); err != nil || requests != 2 {
+		t.Fatalf("GetData error = %v after %v requests; want 2 requests", err, requests)
+	}
+
+	// a new run checks the version and serves the cached response
+	requests = 0
+	f.Cache = &Cache{Dir: dir}
+	file, err := f.GetData()
+	if err != nil || requests != 1 || file.Document.ID != "0:0" {
+		t.Errorf("GetData = %+v, %v after %v requests; want cached file after 1 request", file, err, requests)
+	}
+
+	// the same run does not check the version again
+	requests = 0
+	if _, err := f.GetData(); err != nil || requests != 0 {
+		t.Errorf("GetData error = %v after %v requests; want 0 requests", err, requests)
+	}
+
+	requests = 0
+	lastModified = "2026-02-01T00:00:00Z"
+	f.Cache = &Cache{Dir: dir}
+	if _, err := f.GetData(); err != nil || requests != 2 {
+		t.Errorf("GetData error = %v after %v requests; want 2 requests for a modified file", err, requests)
+	}
+
+	requests = 0
+	f.Cache = &Cache{Dir: dir, Offline: true}
+	file, err = f.GetData()
+	if err != nil || requests != 0 || file.Name != "Design System" {
+		t.Errorf("GetData = %+v, %v after %v requests; want offline file", file, err, requests)
+	}
+
+	_, err = f.GetVariablesData()
+	if !errors.Is(err, ErrNotCached) || requests != 0 {
+		t.Errorf("GetVariablesData error = %v after %v requests; want ErrNotCached", err, requests)
+	}
+}
+
+func TestCacheVersion(t *testing.T) {
+	requests := 0
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("version") != "7" {
+			t.Errorf("unexpected request %v", r.URL)
+		}
+		w.Write([]byte(`{"name":"Design System","version":"7"}`))
+	})
+
+	dir := t.TempDir()
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url, Version: "7", Cache: &Cache{Dir: dir}}
+	for range 2 {
+		if _, err := f.GetData(); err != nil {
+			t.Fatalf("GetData error: %v", err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("GetData made %v requests; want 1 for a pinned version", requests)
+	}
+}
+
+func TestCacheVersionNodes(t *testing.T) {
+	requests := 0
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("depth") == "1" {
+			t.Errorf("unexpected latest version request %v", r.URL)
+		}
+		requests++
+		w.Write([]byte(`{"name":"Design System","version":"5","nodes":{}}`))
+	})
+
+	dir := t.TempDir()
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url, Cache: &Cache{Dir: dir}}
+	for range 2 {
+		if _, err := f.GetNodes([]string{"1:2"}, NodesOptions{Version: "5"}); err != nil {
+			t.Fatalf("GetNodes error: %v", err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("GetNodes made %v requests; want 1 for a pinned version", requests)
+	}
+
+	uri, _ := f.getNodesUri([]string{"1:2"}, NodesOptions{Version: "5"})
+	if _, entry, err := f.Cache.read(f.FILE_KEY, uri); err != nil || entry.Version != "5" {
+		t.Errorf("cached version = %v, %v; want 5", entry.Version, err)
+	}
+}
+
+func TestCacheVersionVariables(t *testing.T) {
+	requests := 0
+	lastModified := "2026-01-01T00:00:00Z"
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("depth") == "1" {
+			w.Write([]byte(`{"version":"8","lastModified":"` + lastModified + `"}`))
+			return
+		}
+		requests++
+		w.Write([]byte(`{"status":200,"meta":{}}`))
+	})
+
+	dir := t.TempDir()
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url, Version: "7", Cache: &Cache{Dir: dir}}
+	if _, err := f.GetVariablesData(); err != nil || requests != 1 {
+		t.Fatalf("GetVariablesData error = %v after %v requests; want 1 request", err, requests)
+	}
+
+	// variables have no version parameter, a modified file refreshes them
+	lastModified = "2026-02-01T00:00:00Z"
+	f.Cache = &Cache{Dir: dir}
+	if _, err := f.GetVariablesData(); err != nil || requests != 2 {
+		t.Errorf("GetVariablesData error = %v after %v requests; want 2 requests for a modified file", err, requests)
+	}
+}
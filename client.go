@@ -0,0 +1,114 @@
+package figo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Figma REST API host, all endpoints are relative to it.
+const DefaultBaseUrl = "https://api.figma.com"
+
+func (f *Figma) baseUrl() string {
+	if f.BaseUrl != "" {
+		return strings.TrimSuffix(f.BaseUrl, "/")
+	}
+	return DefaultBaseUrl
+}
+
+func (f *Figma) client() *http.Client {
+	if f.HttpClient != nil {
+		return f.HttpClient
+	}
+
+	// Create a new HTTP client with a timeout
+	return &http.Client{
+		Transport: f.Transport,
+		Timeout:   10 * time.Second, // may need longer timeout as figma files tend to get big
+	}
+}
+
+func (f *Figma) newRequest(ctx context.Context, uri string) (*http.Request, error) {
+	req, requestError := http.NewRequestWithContext(ctx, "GET", uri, nil)
+	if requestError != nil {
+		return nil, requestError
+	}
+
+	if f.UserAgent != "" {
+		req.Header.Set("User-Agent", f.UserAgent)
+	}
+
+	return req, nil
+}
+
+// do sends a GET request, retrying rate limited and failed requests with the retry policy.
+// The Figma token is only sent to API endpoints, image urls are signed and don't need it.
+func (f *Figma) do(ctx context.Context, uri string, token bool) (*http.Response, error) {
+	policy := f.retryPolicy()
+
+	for attempt := 0; ; attempt++ {
+		req, requestError := f.newRequest(ctx, uri)
+		if requestError != nil {
+			return nil, requestError
+		}
+
+		if token {
+			req.Header.Set("X-Figma-Token", f.API_KEY)
+		}
+
+		start := time.Now()
+		httpResp, httpError := f.client().Do(req)
+		if httpResp != nil && httpResp.StatusCode == http.StatusOK {
+			f.logger().Debug("figma request", "endpoint", req.URL.Path, "status", httpResp.StatusCode, "attempt", attempt, "duration", time.Since(start))
+			return httpResp, nil
+		}
+
+		wait := policy.backoff(attempt)
+		if httpError != nil {
+			f.logger().Debug("figma request failed", "endpoint", req.URL.Path, "error", httpError, "attempt", attempt, "duration", time.Since(start))
+
+			if ctx.Err() != nil || attempt >= policy.MaxRetries {
+				return nil, httpError
+			}
+		} else {
+			f.logger().Debug("figma request", "endpoint", req.URL.Path, "status", httpResp.StatusCode, "attempt", attempt, "duration", time.Since(start))
+
+			apiError := newApiError(req, httpResp)
+
+			// drain the body so the connection can be reused
+			io.Copy(io.Discard, httpResp.Body)
+			httpResp.Body.Close()
+
+			if !isRetryable(httpResp.StatusCode) || attempt >= policy.MaxRetries {
+				return nil, apiError
+			}
+
+			if after, ok := retryAfter(httpResp); ok {
+				if after > policy.MaxBackoff {
+					return nil, apiError
+				}
+				wait = after
+			}
+		}
+
+		f.logger().Info("retrying figma request", "endpoint", req.URL.Path, "attempt", attempt+1, "wait", wait)
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// get requests a Figma API endpoint and returns the response body.
+func (f *Figma) get(ctx context.Context, uri string) ([]byte, error) {
+	httpResp, httpError := f.do(ctx, uri, true)
+	if httpError != nil {
+		return nil, httpError
+	}
+
+	defer httpResp.Body.Close()
+
+	return io.ReadAll(httpResp.Body)
+}
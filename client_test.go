@@ -0,0 +1,66 @@
+package figo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func TestGetDataBaseUrl(t *testing.T) {
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/files/KEY" || r.Header.Get("User-Agent") != "figo-test" || r.Header.Get("X-Figma-Token") != "TOKEN" {
+			t.Errorf("unexpected request %v %v", r.URL, r.Header)
+		}
+		w.Write([]byte(`{"name":"Design System","document":{"id":"0:0","type":"DOCUMENT"}}`))
+	})
+
+	f := Figma{FILE_KEY: "KEY", API_KEY: "TOKEN", BaseUrl: url + "/", UserAgent: "figo-test"}
+	file, err := f.GetData()
+	if err != nil {
+		t.Fatalf("GetData error: %v", err)
+	}
+
+	if file.Name != "Design System" || file.Document.ID != "0:0" {
+		t.Errorf("GetData = %+v", file)
+	}
+}
+
+func TestGetDataTransport(t *testing.T) {
+	var uri string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		uri = req.URL.String()
+		return nil, errors.New("offline")
+	})
+
+	f := Figma{FILE_KEY: "KEY", Transport: transport, Retry: &RetryPolicy{}}
+	_, err := f.GetVariablesData()
+	if err == nil {
+		t.Errorf("GetVariablesData error = nil; want transport error")
+	}
+
+	if uri != "https://api.figma.com/v1/files/KEY/variables/local" {
+		t.Errorf("GetVariablesData requested %v", uri)
+	}
+}
+
+func TestGetDataContext(t *testing.T) {
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %v", r.URL)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url}
+	_, err := f.GetDataContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GetDataContext error = %v; want %v", err, context.Canceled)
+	}
+}
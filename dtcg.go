@@ -0,0 +1,303 @@
+package figo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vpaulo/figo/figma"
+	fg "github.com/vpaulo/figo/figma"
+)
+
+// Figma mode values of a token when DTCGOptions.ModeExtensions is set, keyed by mode name.
+const DTCGModesExtension = "com.figma.modes"
+
+// Figma code syntax of a token, keyed like figma.VariableCodeSyntax, e.g. {"WEB": "--link"}.
+const DTCGCodeSyntaxExtension = "com.figma.codeSyntax"
+
+type DTCGOptions struct {
+	ModeExtensions bool // Other modes are written in the token $extensions instead of their own files
+}
+
+// ExportDTCG writes the variables in the Design Tokens Community Group format, one file per collection.
+// The default mode is in <collection>.tokens.json and every other mode in <collection>.<mode>.tokens.json,
+// unless they are written as extensions. Variables are grouped by collection and by the "/" in their
+// names, aliases are written as references and the ones that can not be resolved are reported in the error.
+// Aliases to variables that are not exported, e.g. deleted, are written with their value. Code syntax
+// names are kept in the DTCGCodeSyntaxExtension.
+func (f *Figma) ExportDTCG(variables figma.Variables, options DTCGOptions) (map[string][]byte, error) {
+	meta := variables.Meta
+	files := make(map[string]fg.DesignTokens)
+	var errs []error
+
+	for _, v := range meta.Variables {
+		collection, ok := meta.VariableCollections[v.VariableCollectionId]
+		if !f.exported(meta, v) || !ok {
+			continue
+		}
+
+		path := designTokenPath(collection, v)
+
+		for _, mode := range defaultModeFirst(collection) {
+			value, ok := v.ValuesByMode[mode.ModeId]
+			if !ok {
+				continue
+			}
+
+			resolved, err := meta.Resolve(v, mode.ModeId)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			token := fg.DesignToken{
+				Type:        f.variableType(v),
+				Value:       f.designTokenValue(meta, v, value, resolved),
+				Description: v.Description,
+			}
+			if codeSyntax := designTokenCodeSyntax(v.CodeSyntax); len(codeSyntax) > 0 {
+				token.Extensions = map[string]any{DTCGCodeSyntaxExtension: codeSyntax}
+			}
+
+			file := fg.ToKebabCase(collection.Name)
+			isDefault := mode.ModeId == collection.DefaultModeId
+
+			if options.ModeExtensions && !isDefault {
+				defaultToken, ok := files[file][path]
+				if !ok {
+					continue // the default value could not be resolved
+				}
+				if defaultToken.Extensions == nil {
+					defaultToken.Extensions = make(map[string]any)
+				}
+				modes, _ := defaultToken.Extensions[DTCGModesExtension].(map[string]any)
+				if modes == nil {
+					modes = make(map[string]any)
+					defaultToken.Extensions[DTCGModesExtension] = modes
+				}
+				modes[mode.Name] = token.Value
+				files[file][path] = defaultToken
+				continue
+			}
+
+			if !isDefault {
+				file += "." + fg.ToKebabCase(mode.Name)
+			}
+
+			if files[file] == nil {
+				files[file] = make(fg.DesignTokens)
+			}
+			files[file][path] = token
+		}
+	}
+
+	output := make(map[string][]byte)
+	for name, tokens := range files {
+		data, err := json.MarshalIndent(tokens, "", "  ")
+		if err != nil {
+			return output, err
+		}
+		output[name+".tokens.json"] = data
+	}
+
+	return output, errors.Join(errs...)
+}
+
+// ImportDTCG reads files written by ExportDTCG, or by other tools using the same file names,
+// into tokens for GenerateTokensCSS. Custom properties are named like ParseVariables does, by their
+// WEB code syntax when they have one, and references become var() of the referenced token.
+func (f *Figma) ImportDTCG(files map[string][]byte) (map[string]figma.Token, error) {
+	tokens := make(map[string]figma.Token)
+	selector := f.themeSelector()
+	parsed := make(map[string]fg.DesignTokens)
+	variables := make(map[string]string) // token path to custom property
+
+	for name, data := range files {
+		var designTokens fg.DesignTokens
+		if err := json.Unmarshal(data, &designTokens); err != nil {
+			return tokens, fmt.Errorf("%v: %w", name, err)
+		}
+		parsed[name] = designTokens
+
+		for path, designToken := range designTokens {
+			variables[path] = designTokenVariable(path, designToken)
+		}
+	}
+
+	for name, designTokens := range parsed {
+
+		// modes are only known by name, the file without a mode is the default one
+		collection, mode, _ := strings.Cut(strings.TrimSuffix(strings.TrimSuffix(name, ".json"), ".tokens"), ".")
+		modeSelector := func(mode string) string {
+			return selector(fg.VariableCollection{Name: collection}, fg.Modes{ModeId: mode, Name: mode})
+		}
+
+		for path, designToken := range designTokens {
+			token := figma.Token{
+				Name:     strings.Join(strings.Split(path, ".")[1:], "/"),
+				Variable: variables[path],
+				Theme:    fg.ToKebabCase(mode),
+				Mode:     mode,
+				Selector: ":root",
+
+				Type:        designToken.Type,
+				Description: designToken.Description,
+				Collection:  strings.Split(path, ".")[0],
+			}
+			if mode != "" {
+				token.Selector = modeSelector(mode)
+			}
+
+			value, err := designTokenCss(designToken.Type, designToken.Value, variables)
+			if err != nil {
+				return tokens, fmt.Errorf("%v: %v: %w", name, path, err)
+			}
+			token.Value = value
+			tokens[fg.TokenKey(path, mode)] = token
+
+			modes, _ := designToken.Extensions[DTCGModesExtension].(map[string]any)
+			for modeName, modeValue := range modes {
+				value, err := designTokenCss(designToken.Type, modeValue, variables)
+				if err != nil {
+					return tokens, fmt.Errorf("%v: %v: %v: %w", name, path, modeName, err)
+				}
+
+				token.Value = value
+				token.Theme = fg.ToKebabCase(modeName)
+				token.Mode = modeName
+				token.Selector = modeSelector(modeName)
+				tokens[fg.TokenKey(path, modeName)] = token
+			}
+		}
+	}
+
+	return tokens, nil
+}
+
+func defaultModeFirst(collection fg.VariableCollection) []fg.Modes {
+	var modes []fg.Modes
+	for _, mode := range collection.Modes {
+		if mode.ModeId == collection.DefaultModeId {
+			modes = append([]fg.Modes{mode}, modes...)
+		} else {
+			modes = append(modes, mode)
+		}
+	}
+	return modes
+}
+
+func designTokenPath(collection fg.VariableCollection, v fg.Variable) string {
+	names := []string{fg.DesignTokenName(collection.Name)}
+	for _, name := range strings.Split(v.Name, "/") {
+		names = append(names, fg.DesignTokenName(name))
+	}
+	return strings.Join(names, ".")
+}
+
+// designTokenVariable matches the variable names of ParseVariables, the WEB code syntax or the
+// collection and variable name in kebab case.
+func designTokenVariable(path string, token fg.DesignToken) string {
+	codeSyntax, _ := token.Extensions[DTCGCodeSyntaxExtension].(map[string]any)
+	web, _ := codeSyntax["WEB"].(string)
+	if name := webName(fg.VariableCodeSyntax{Web: web}); name != "" {
+		return "--" + name
+	}
+	return "--" + fg.ToKebabCase(strings.ReplaceAll(path, ".", " "))
+}
+
+func designTokenCodeSyntax(codeSyntax fg.VariableCodeSyntax) map[string]any {
+	names := make(map[string]any)
+	if codeSyntax.Web != "" {
+		names["WEB"] = codeSyntax.Web
+	}
+	if codeSyntax.Android != "" {
+		names["ANDROID"] = codeSyntax.Android
+	}
+	if codeSyntax.Ios != "" {
+		names["iOS"] = codeSyntax.Ios
+	}
+	return names
+}
+
+// DTCG has no string or boolean types, they keep the Figma type names. FLOAT variables
+// can also be numbers and font weights, see variableType.
+func designTokenType(resolvedType fg.ResolvedType) string {
+	switch resolvedType {
+	case fg.ResolvedTypeColor:
+		return "color"
+	case fg.ResolvedTypeFloat:
+		return "dimension"
+	case fg.ResolvedTypeString:
+		return "string"
+	case fg.ResolvedTypeBoolean:
+		return "boolean"
+	}
+	return ""
+}
+
+// designTokenValue references the token of aliases to exported variables, other values are the resolved literal.
+func (f *Figma) designTokenValue(meta fg.Meta, v fg.Variable, value, resolved fg.VariableValue) any {
+	alias, ok := f.aliasTarget(meta, value)
+	if !ok {
+		value = resolved
+	}
+
+	switch value.Kind {
+	case fg.VariableValueKindColor:
+		return fg.DesignTokenColorValue(value.Color)
+	case fg.VariableValueKindFloat:
+		number, unit := f.variableFloat(v, value.Float)
+		if unit == "" {
+			return number
+		}
+		return map[string]any{"value": number, "unit": unit}
+	case fg.VariableValueKindString:
+		return value.String
+	case fg.VariableValueKindBoolean:
+		return value.Boolean
+	case fg.VariableValueKindAlias:
+		return "{" + designTokenPath(meta.VariableCollections[alias.VariableCollectionId], alias) + "}"
+	}
+	return nil
+}
+
+// designTokenCss converts a token value to CSS, variables has the custom properties of the imported tokens.
+func designTokenCss(tokenType string, value any, variables map[string]string) (string, error) {
+	if path, ok := fg.DesignTokenReference(value); ok {
+		variable, found := variables[path]
+		if !found {
+			variable = designTokenVariable(path, fg.DesignToken{})
+		}
+		return fmt.Sprintf("var(%v)", variable), nil
+	}
+
+	switch tokenType {
+	case "color":
+		color, err := fg.DesignTokenColor(value)
+		return color.Rgba(), err
+	case "dimension", "duration":
+		if value, ok := value.(map[string]any); ok {
+			return fmt.Sprintf("%v%v", value["value"], value["unit"]), nil
+		}
+	case "fontFamily":
+		if families, ok := value.([]any); ok {
+			var names []string
+			for _, family := range families {
+				names = append(names, strconv.Quote(fmt.Sprint(family)))
+			}
+			return strings.Join(names, ", "), nil
+		}
+		return strconv.Quote(fmt.Sprint(value)), nil
+	case "string":
+		return strconv.Quote(fmt.Sprint(value)), nil
+	}
+
+	switch value := value.(type) {
+	case string, float64, bool:
+		return fmt.Sprint(value), nil
+	}
+
+	return "", fmt.Errorf("unsupported %v value %v", tokenType, value)
+}
@@ -0,0 +1,224 @@
+package figo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/vpaulo/figo/figma"
+)
+
+var aliasedVariables = []byte(`{"meta": {
+	"variableCollections": {
+		"C:1": {"id": "C:1", "name": "Primitives", "defaultModeId": "1:0", "modes": [{"modeId": "1:0", "name": "Value"}]},
+		"C:2": {"id": "C:2", "name": "Color", "defaultModeId": "2:0", "modes": [{"modeId": "2:1", "name": "Dark"}, {"modeId": "2:0", "name": "Light"}]}
+	},
+	"variables": {
+		"V:1": {"id": "V:1", "name": "Red/500", "variableCollectionId": "C:1", "resolvedType": "COLOR", "valuesByMode": {"1:0": {"r": 1, "g": 0, "b": 0, "a": 1}}},
+		"V:2": {"id": "V:2", "name": "Space/Small", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "valuesByMode": {"1:0": 4}},
+		"V:3": {"id": "V:3", "name": "Danger", "description": "Errors and destructive actions", "variableCollectionId": "C:2", "resolvedType": "COLOR", "valuesByMode": {
+			"2:0": {"type": "VARIABLE_ALIAS", "id": "V:1"},
+			"2:1": {"r": 0.5, "g": 0, "b": 0, "a": 1}
+		}},
+		"V:4": {"id": "V:4", "name": "Remote", "variableCollectionId": "C:2", "resolvedType": "COLOR", "valuesByMode": {"2:0": {"type": "VARIABLE_ALIAS", "id": "V:99"}}}
+	}
+}}`)
+
+func TestExportDTCG(t *testing.T) {
+	var variables figma.Variables
+	if err := json.Unmarshal(aliasedVariables, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	files, err := f.ExportDTCG(variables, DTCGOptions{})
+	if !errors.Is(err, figma.ErrAliasNotFound) {
+		t.Errorf("ExportDTCG error = %v; want alias not found", err)
+	}
+
+	want := map[string]string{
+		"primitives.tokens.json": `"$type": "dimension"`,
+		"color.tokens.json":      `"$value": "{Primitives.Red.500}"`,
+		"color.dark.tokens.json": `"$description": "Errors and destructive actions"`,
+	}
+
+	if len(files) != len(want) {
+		t.Errorf("ExportDTCG files = %v; want %v", len(files), len(want))
+	}
+
+	for name, content := range want {
+		if !strings.Contains(string(files[name]), content) {
+			t.Errorf("%+v = %s; want %v", name, files[name], content)
+		}
+	}
+
+	// tokens read back match the ones parsed from the variables
+	imported, err := f.ImportDTCG(files)
+	if err != nil {
+		t.Fatalf("ImportDTCG error: %v", err)
+	}
+
+	parsed := f.ParseVariables(variables)
+	if len(imported) != len(parsed) {
+		t.Errorf("ImportDTCG = %+v; want %v tokens", imported, len(parsed))
+	}
+
+	for _, token := range parsed {
+		key := figma.TokenKey(strings.TrimPrefix(token.Variable, "--"), token.Mode)
+		found := false
+		for _, ans := range imported {
+			if ans.Variable == token.Variable && ans.Value == token.Value && ans.CssSelector() == token.CssSelector() {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%+v = missing; want %+v", key, token)
+		}
+	}
+}
+
+func TestExportDTCGModeExtensions(t *testing.T) {
+	var variables figma.Variables
+	if err := json.Unmarshal(aliasedVariables, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	files, _ := f.ExportDTCG(variables, DTCGOptions{ModeExtensions: true})
+	if len(files) != 2 || !strings.Contains(string(files["color.tokens.json"]), DTCGModesExtension) {
+		t.Fatalf("ExportDTCG files = %v", files)
+	}
+
+	tokens, err := f.ImportDTCG(files)
+	if err != nil {
+		t.Fatalf("ImportDTCG error: %v", err)
+	}
+
+	light := tokens[figma.TokenKey("Color.Danger", "")]
+	if light.Value != "var(--primitives-red-500)" || light.CssSelector() != ":root" {
+		t.Errorf("Color.Danger = %+v; want reference in :root", light)
+	}
+
+	dark := tokens[figma.TokenKey("Color.Danger", "Dark")]
+	if dark.Value != "rgba(127,0,0,1)" || dark.CssSelector() != `[data-color="dark"]` {
+		t.Errorf("Color.Danger/Dark = %+v; want dark value", dark)
+	}
+}
+
+func TestExportDTCGScopes(t *testing.T) {
+	var variables figma.Variables
+
+	data := []byte(`{"meta": {
+		"variableCollections": {
+			"C:1": {"id": "C:1", "name": "Size", "defaultModeId": "1:0", "modes": [{"modeId": "1:0", "name": "Default"}]}
+		},
+		"variables": {
+			"V:1": {"id": "V:1", "name": "Gap", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["GAP"], "valuesByMode": {"1:0": 8}},
+			"V:2": {"id": "V:2", "name": "Bold", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["FONT_WEIGHT"], "valuesByMode": {"1:0": 700}},
+			"V:3": {"id": "V:3", "name": "Faded", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["OPACITY"], "valuesByMode": {"1:0": 50}}
+		}
+	}}`)
+
+	if err := json.Unmarshal(data, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	files, err := f.ExportDTCG(variables, DTCGOptions{})
+	if err != nil {
+		t.Fatalf("ExportDTCG error: %v", err)
+	}
+
+	var tokens figma.DesignTokens
+	if err := json.Unmarshal(files["size.tokens.json"], &tokens); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	want := map[string]figma.DesignToken{
+		"Size.Gap":   {Type: "dimension", Value: map[string]any{"value": 8.0, "unit": "px"}},
+		"Size.Bold":  {Type: "fontWeight", Value: 700.0},
+		"Size.Faded": {Type: "number", Value: 0.5},
+	}
+
+	for path, token := range want {
+		ans := tokens[path]
+		if ans.Type != token.Type || fmt.Sprint(ans.Value) != fmt.Sprint(token.Value) {
+			t.Errorf("%+v = %+v; want %+v", path, ans, token)
+		}
+	}
+}
+
+func TestExportDTCGDeletedAlias(t *testing.T) {
+	var variables figma.Variables
+	if err := json.Unmarshal(deletedAliasVariables, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	files, err := f.ExportDTCG(variables, DTCGOptions{})
+	if err != nil {
+		t.Fatalf("ExportDTCG error: %v", err)
+	}
+
+	want := map[string]string{
+		"color.tokens.json":      "{Color.Blue}",
+		"color.dark.tokens.json": "hex:#ff0000",
+	}
+
+	for name, value := range want {
+		var tokens figma.DesignTokens
+		if err := json.Unmarshal(files[name], &tokens); err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+
+		if ans := tokens["Color.Link"].Value; !strings.Contains(fmt.Sprint(ans), value) || strings.Contains(string(files[name]), "Color.Old") {
+			t.Errorf("%+v = %v; want %v", name, ans, value)
+		}
+	}
+}
+
+func TestImportDTCGCodeSyntax(t *testing.T) {
+	var variables figma.Variables
+
+	data := []byte(`{"meta": {
+		"variableCollections": {
+			"C:1": {"id": "C:1", "name": "Color", "defaultModeId": "1:0", "modes": [{"modeId": "1:0", "name": "Light"}, {"modeId": "1:1", "name": "Dark"}]}
+		},
+		"variables": {
+			"V:1": {"id": "V:1", "name": "Blue", "variableCollectionId": "C:1", "resolvedType": "COLOR", "codeSyntax": {"WEB": "var(--blue)", "iOS": "blue"}, "valuesByMode": {"1:0": {"r": 0, "g": 0, "b": 1, "a": 1}, "1:1": {"r": 0, "g": 0, "b": 0.5, "a": 1}}},
+			"V:2": {"id": "V:2", "name": "Text/Link", "variableCollectionId": "C:1", "resolvedType": "COLOR", "codeSyntax": {"WEB": "--link"}, "valuesByMode": {"1:0": {"type": "VARIABLE_ALIAS", "id": "V:1"}, "1:1": {"type": "VARIABLE_ALIAS", "id": "V:1"}}}
+		}
+	}}`)
+
+	if err := json.Unmarshal(data, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	// modes are named by the file name or by the extension
+	modes := map[bool]string{false: "dark", true: "Dark"}
+	for _, options := range []DTCGOptions{{}, {ModeExtensions: true}} {
+		files, err := f.ExportDTCG(variables, options)
+		if err != nil {
+			t.Fatalf("ExportDTCG error: %v", err)
+		}
+
+		if !strings.Contains(string(files["color.tokens.json"]), DTCGCodeSyntaxExtension) {
+			t.Errorf("color.tokens.json = %s; want %v", files["color.tokens.json"], DTCGCodeSyntaxExtension)
+		}
+
+		tokens, err := f.ImportDTCG(files)
+		if err != nil {
+			t.Fatalf("ImportDTCG error: %v", err)
+		}
+
+		for _, mode := range []string{"", modes[options.ModeExtensions]} {
+			link := tokens[figma.TokenKey("Color.Text.Link", mode)]
+			if link.Variable != "--link" || link.Value != "var(--blue)" {
+				t.Errorf("%+v Color.Text.Link/%v = %+v; want --link: var(--blue)", options, mode, link)
+			}
+		}
+	}
+}
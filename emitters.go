@@ -0,0 +1,183 @@
+package figo
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/vpaulo/figo/figma"
+	fg "github.com/vpaulo/figo/figma"
+)
+
+// TokenEmitter writes tokens in an output format, see GenerateTokens.
+type TokenEmitter interface {
+	Emit(tokens map[string]figma.Token) (string, error)
+}
+
+// GenerateTokens writes the tokens with an emitter, e.g. CssEmitter, ScssEmitter, LessEmitter or TypeScriptEmitter.
+func (f *Figma) GenerateTokens(tokens map[string]figma.Token, emitter TokenEmitter) (string, error) {
+	return emitter.Emit(tokens)
+}
+
+// CssEmitter writes custom properties grouped by selector and the text and grid style classes.
+type CssEmitter struct{}
+
+func (e CssEmitter) Emit(tokens map[string]figma.Token) (string, error) {
+	tk := make(map[string][]string)
+	variables := make(map[string][]string)
+
+	// Group tokens by selector and generate CSS rules
+	for _, token := range tokens {
+		if token.ClassName != "" {
+			tk["."+token.ClassName] = strings.Split(token.Value, "|")
+		} else {
+			selector := token.CssSelector()
+			variables[selector] = append(variables[selector], fmt.Sprintf("%s: %s;", token.Variable, token.Value))
+		}
+	}
+
+	for selector, rules := range variables {
+		// Sort rules alphabetically (case-insensitive)
+		sort.Slice(rules, func(i, j int) bool {
+			return strings.ToLower(rules[i]) < strings.ToLower(rules[j])
+		})
+		tk[selector] = removeDuplicates(rules)
+	}
+
+	var blocks []cssBlock
+	for _, selector := range slices.SortedFunc(maps.Keys(tk), compareSelectors) {
+		blocks = append(blocks, cssBlock{Selector: selector, Rules: tk[selector]})
+	}
+
+	var out bytes.Buffer
+	tmp := figma.CreateTmpl("tokens", figma.CssVariablesTemplate)
+	err := tmp.Execute(&out, blocks)
+	if err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+type cssBlock struct {
+	Selector string
+	Rules    []string
+}
+
+// Mode selectors like .dark have the specificity of :root, they must come after it to override
+// its values, and media queries come last.
+func compareSelectors(a string, b string) int {
+	rank := func(selector string) int {
+		switch {
+		case selector == ":root":
+			return 0
+		case strings.HasPrefix(selector, "@"):
+			return 2
+		}
+		return 1
+	}
+	return cmp.Or(cmp.Compare(rank(a), rank(b)), strings.Compare(a, b))
+}
+
+// TemplateEmitter executes a template with the figma.TokenData of the tokens.
+type TemplateEmitter struct {
+	Name     string
+	Template string
+}
+
+var (
+	ScssEmitter       = TemplateEmitter{Name: "scss", Template: fg.ScssTokensTemplate}             // Variables, a map per theme and text style mixins
+	LessEmitter       = TemplateEmitter{Name: "less", Template: fg.LessTokensTemplate}             // Variables, a map per theme and text style mixins
+	TypeScriptEmitter = TemplateEmitter{Name: "typescript", Template: fg.TypeScriptTokensTemplate} // A tokens object per theme, the Theme type and text styles
+)
+
+func (e TemplateEmitter) Emit(tokens map[string]figma.Token) (string, error) {
+	var out bytes.Buffer
+	tmp := figma.CreateTmpl(e.Name, e.Template)
+	err := tmp.Execute(&out, tokenData(tokens))
+	if err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+// tokenData groups the custom property tokens by theme, a variable is only kept once per theme.
+func tokenData(tokens map[string]figma.Token) fg.TokenData {
+	var data fg.TokenData
+	themes := make(map[string]*fg.TokenTheme)
+
+	keys := slices.Sorted(maps.Keys(tokens))
+	for _, key := range keys {
+		token := tokens[key]
+
+		if token.ClassName != "" {
+			class := fg.TokenClass{Name: token.ClassName, Key: fg.ToCamelCase(token.ClassName)}
+			for _, rule := range strings.Split(token.Value, "|") {
+				property, value, ok := strings.Cut(strings.TrimSuffix(rule, ";"), ":")
+				if ok {
+					class.Properties = append(class.Properties, tokenValue(strings.TrimSpace(property), strings.TrimSpace(value)))
+				}
+			}
+			if token.Type == "grid" {
+				data.Grids = append(data.Grids, class)
+			} else {
+				data.Classes = append(data.Classes, class)
+			}
+			continue
+		}
+
+		if !strings.HasPrefix(token.Variable, "--") {
+			continue // not a custom property, e.g. color-scheme
+		}
+
+		name := tokenTheme(token)
+		theme, ok := themes[name]
+		if !ok {
+			theme = &fg.TokenTheme{Name: name, Selector: token.CssSelector()}
+			themes[name] = theme
+		}
+
+		variable := strings.TrimPrefix(token.Variable, "--")
+		if !slices.ContainsFunc(theme.Tokens, func(value fg.TokenValue) bool { return value.Name == variable }) {
+			theme.Tokens = append(theme.Tokens, tokenValue(variable, token.Value))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(themes)) {
+		theme := *themes[name]
+		slices.SortFunc(theme.Tokens, func(a, b fg.TokenValue) int { return strings.Compare(a.Name, b.Name) })
+
+		if name == "default" {
+			data.Themes = append([]fg.TokenTheme{theme}, data.Themes...)
+		} else {
+			data.Themes = append(data.Themes, theme)
+		}
+	}
+
+	compareClasses := func(a, b fg.TokenClass) int { return strings.Compare(a.Name, b.Name) }
+	slices.SortFunc(data.Classes, compareClasses)
+	slices.SortFunc(data.Grids, compareClasses)
+
+	return data
+}
+
+func tokenTheme(token figma.Token) string {
+	if token.CssSelector() == ":root" {
+		return "default"
+	}
+	if token.Theme != "" {
+		return fg.ToKebabCase(token.Theme)
+	}
+	return fg.ToKebabCase(token.Mode)
+}
+
+func tokenValue(name string, value string) fg.TokenValue {
+	quoted, _ := json.Marshal(value)
+	return fg.TokenValue{Name: name, Key: fg.ToCamelCase(name), Value: value, Quoted: string(quoted)}
+}
@@ -0,0 +1,138 @@
+package figo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/vpaulo/figo/figma"
+)
+
+var emitterTokens = map[string]figma.Token{
+	"V:1/1:0":      {Variable: "--color-surface", Value: "rgba(255,255,255,1)", Theme: "light", Selector: ":root"},
+	"V:1/1:1":      {Variable: "--color-surface", Value: "rgba(0,0,0,1)", Theme: "dark", Selector: `[data-theme="dark"]`},
+	"V:1/1:1/@":    {Variable: "--color-surface", Value: "rgba(0,0,0,1)", Theme: "dark", Selector: colorSchemeMedia},
+	"V:2/2:0":      {Variable: "--space-small", Value: "4px", Theme: "default", Selector: ":root"},
+	"S:1":          {Value: "font-family: Inter;|font-size: 32px;", ClassName: "text__style--heading"},
+	"color-scheme": {Variable: "color-scheme", Value: "light dark", Selector: ":root"},
+}
+
+func TestEmitters(t *testing.T) {
+	tests := []struct {
+		emitter TokenEmitter
+		want    string
+	}{
+		{ScssEmitter, `$color-surface: rgba(255,255,255,1);
+$space-small: 4px;
+
+$tokens-default: (
+	"color-surface": (rgba(255,255,255,1)),
+	"space-small": (4px),
+);
+
+$tokens-dark: (
+	"color-surface": (rgba(0,0,0,1)),
+);
+
+$themes: (
+	"default": $tokens-default,
+	"dark": $tokens-dark,
+);
+
+@mixin text__style--heading {
+	font-family: Inter;
+	font-size: 32px;
+}
+`},
+		{LessEmitter, `@color-surface: rgba(255,255,255,1);
+@space-small: 4px;
+
+@tokens-default: {
+	color-surface: rgba(255,255,255,1);
+	space-small: 4px;
+}
+
+@tokens-dark: {
+	color-surface: rgba(0,0,0,1);
+}
+
+.text__style--heading() {
+	font-family: Inter;
+	font-size: 32px;
+}
+`},
+		{TypeScriptEmitter, `export const tokens = {
+	"default": {
+		"colorSurface": "rgba(255,255,255,1)",
+		"spaceSmall": "4px",
+	},
+	"dark": {
+		"colorSurface": "rgba(0,0,0,1)",
+	},
+} as const;
+
+export type Theme = keyof typeof tokens;
+
+export const textStyles = {
+	"textStyleHeading": {
+		"fontFamily": "Inter",
+		"fontSize": "32px",
+	},
+} as const;
+`},
+	}
+
+	f := Figma{}
+	for _, test := range tests {
+		ans, err := f.GenerateTokens(emitterTokens, test.emitter)
+		if err != nil || ans != test.want {
+			t.Errorf("%+v = %v, %v; want %v", test.emitter, ans, err, test.want)
+		}
+	}
+}
+
+type countEmitter struct{}
+
+func (countEmitter) Emit(tokens map[string]figma.Token) (string, error) {
+	return fmt.Sprint(len(tokens)), nil
+}
+
+// any TokenEmitter can be plugged in
+func TestGenerateTokensCustomEmitter(t *testing.T) {
+	f := Figma{}
+	if ans, _ := f.GenerateTokens(emitterTokens, countEmitter{}); ans != "6" {
+		t.Errorf("GenerateTokens = %v; want 6", ans)
+	}
+}
+
+// comma separated values are lists, Sass maps need them in parentheses
+func TestScssEmitterLists(t *testing.T) {
+	tokens := map[string]figma.Token{
+		"S:1": {Variable: "--elevation-high", Value: "0px 1px 2px 0px rgba(0,0,0,0.25), 0px 4px 8px 0px rgba(0,0,0,0.1)", Theme: ":root"},
+	}
+
+	f := Figma{}
+	ans, err := f.GenerateTokens(tokens, ScssEmitter)
+	want := `"elevation-high": (0px 1px 2px 0px rgba(0,0,0,0.25), 0px 4px 8px 0px rgba(0,0,0,0.1)),`
+	if err != nil || !strings.Contains(ans, want) {
+		t.Errorf("GenerateTokens = %v, %v; want %v", ans, err, want)
+	}
+}
+
+func TestTypeScriptEmitterGridStyles(t *testing.T) {
+	tokens := map[string]figma.Token{
+		"S:1": {Value: "font-size: 32px;", ClassName: "text__style--heading", Type: "typography"},
+		"S:2": {Value: "display: grid;", ClassName: "grid__style--desktop", Type: "grid"},
+	}
+
+	f := Figma{}
+	ans, err := f.GenerateTokens(tokens, TypeScriptEmitter)
+	if err != nil {
+		t.Fatalf("GenerateTokens error: %v", err)
+	}
+
+	text, grid, _ := strings.Cut(ans, "export const gridStyles")
+	if strings.Contains(text, "gridStyleDesktop") || !strings.Contains(grid, `"gridStyleDesktop": {`) {
+		t.Errorf("GenerateTokens = %v; want grid styles apart from text styles", ans)
+	}
+}
@@ -0,0 +1,80 @@
+package figo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var (
+	ErrNotFound    = errors.New("figma: not found")
+	ErrForbidden   = errors.New("figma: forbidden, the token is invalid or has no access to the file")
+	ErrRateLimited = errors.New("figma: rate limited")
+)
+
+// ApiError is returned when the Figma API responds with an error status,
+// use errors.As to read it or errors.Is with ErrNotFound, ErrForbidden and ErrRateLimited.
+type ApiError struct {
+	StatusCode int    // HTTP status code
+	Message    string // Figma error message from the response body
+	Endpoint   string // Request path, without the query
+	RequestId  string // Request id header, useful when reporting issues to Figma
+}
+
+func (e *ApiError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+
+	return fmt.Sprintf("figma: %v: HTTP status code is %v: %v", e.Endpoint, e.StatusCode, message)
+}
+
+func (e *ApiError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden || e.StatusCode == http.StatusUnauthorized
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// Error bodies are either {"status": 404, "err": "..."} or {"error": true, "status": 404, "message": "..."}
+// depending on the endpoint.
+type apiErrorBody struct {
+	Err     string `json:"err"`
+	Message string `json:"message"`
+}
+
+func newApiError(req *http.Request, resp *http.Response) *ApiError {
+	apiError := &ApiError{
+		StatusCode: resp.StatusCode,
+		Endpoint:   req.URL.Path,
+		RequestId:  resp.Header.Get("X-Request-Id"),
+	}
+
+	if apiError.RequestId == "" {
+		apiError.RequestId = resp.Header.Get("X-Figma-Request-Id")
+	}
+
+	// error bodies are small, don't read more than needed when a server sends something else
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return apiError
+	}
+
+	var errorBody apiErrorBody
+	if json.Unmarshal(body, &errorBody) == nil {
+		apiError.Message = errorBody.Err
+		if apiError.Message == "" {
+			apiError.Message = errorBody.Message
+		}
+	}
+
+	return apiError
+}
@@ -0,0 +1,60 @@
+package figo
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestApiError(t *testing.T) {
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc-123")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"status":403,"err":"Invalid token"}`))
+	})
+
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url}
+	_, err := f.GetData()
+
+	var apiError *ApiError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("GetData error = %v; want *ApiError", err)
+	}
+
+	want := ApiError{
+		StatusCode: http.StatusForbidden,
+		Message:    "Invalid token",
+		Endpoint:   "/v1/files/KEY",
+		RequestId:  "abc-123",
+	}
+	if *apiError != want {
+		t.Errorf("ApiError = %+v; want %+v", *apiError, want)
+	}
+
+	if !errors.Is(err, ErrForbidden) || errors.Is(err, ErrNotFound) || errors.Is(err, ErrRateLimited) {
+		t.Errorf("ApiError %v matches the wrong sentinel errors", err)
+	}
+
+	if err.Error() != "figma: /v1/files/KEY: HTTP status code is 403: Invalid token" {
+		t.Errorf("Error() = %v", err.Error())
+	}
+}
+
+func TestApiErrorMessage(t *testing.T) {
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":true,"status":404,"message":"File not found"}`))
+	})
+
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url}
+	_, err := f.GetVariablesData()
+
+	var apiError *ApiError
+	if !errors.As(err, &apiError) || apiError.Message != "File not found" {
+		t.Errorf("GetVariablesData error = %v; want File not found", err)
+	}
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(%v, ErrNotFound) = false", err)
+	}
+}
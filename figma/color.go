@@ -51,3 +51,21 @@ func (c *Color) Hsl() string {
 
 	return fmt.Sprintf("hsl(%v,%v%%,%v%%)", math.Round(h*360), math.Round(s*100), math.Round(l*100))
 }
+
+// Hex returns #rrggbb, or #rrggbbaa when the color is not opaque.
+func (c *Color) Hex() string {
+	hex := fmt.Sprintf("#%02x%02x%02x", colorByte(c.Red), colorByte(c.Green), colorByte(c.Blue))
+	if c.Alpha < 1.0 {
+		hex += fmt.Sprintf("%02x", colorByte(c.Alpha))
+	}
+	return hex
+}
+
+// HexArgb returns #AARRGGBB, the format of Android color resources.
+func (c *Color) HexArgb() string {
+	return fmt.Sprintf("#%02X%02X%02X%02X", colorByte(c.Alpha), colorByte(c.Red), colorByte(c.Green), colorByte(c.Blue))
+}
+
+func colorByte(value float64) int {
+	return int(math.Round(math.Max(0, math.Min(1, value)) * 255))
+}
@@ -89,3 +89,50 @@ func TestHsl(t *testing.T) {
 		t.Errorf("%+v = %v; want %v", color, ans, want)
 	}
 }
+
+func TestHex(t *testing.T) {
+	var color Color
+	var ans string
+	var want string
+
+	color = Color{
+		Red:   1.0,
+		Green: 0.5,
+		Blue:  0.0,
+		Alpha: 1,
+	}
+
+	ans = color.Hex()
+	want = "#ff8000"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", color, ans, want)
+	}
+
+	color = Color{
+		Red:   0.1,
+		Green: 0.2,
+		Blue:  0.3,
+		Alpha: 0.5,
+	}
+
+	ans = color.Hex()
+	want = "#1a334d80"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", color, ans, want)
+	}
+}
+
+func TestHexArgb(t *testing.T) {
+	color := Color{
+		Red:   0.1,
+		Green: 0.2,
+		Blue:  0.3,
+		Alpha: 0.5,
+	}
+
+	ans := color.HexArgb()
+	want := "#801A334D"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", color, ans, want)
+	}
+}
@@ -1,10 +1,144 @@
 package figma
 
 import (
+	"cmp"
+	"encoding/json"
 	"reflect"
 	"strconv"
 )
 
+// The types with `default` tags set them while being unmarshalled, the default values
+// are filled in first and the JSON only overrides the fields it has.
+
+func DefaultNode() Node {
+	return nodeJSON{}.node()
+}
+
+func DefaultPaint() Paint {
+	return Paint{
+		Visible: boolPointer(true),
+		Opacity: 1,
+	}
+}
+
+func DefaultEffect() Effect {
+	return Effect{
+		Visible: boolPointer(true),
+	}
+}
+
+func DefaultLayoutGrid() LayoutGrid {
+	return LayoutGrid{
+		Visible: boolPointer(true),
+	}
+}
+
+// Node does not use the same approach as the other types, a method on a recursive type makes
+// encoding/json scan each node again for every parent it has. The whole tree is decoded once
+// into nodeJSON instead, which records the float fields that were missing, and then converted.
+func (n *Node) UnmarshalJSON(data []byte) error {
+	var value nodeJSON
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*n = value.node()
+	return nil
+}
+
+type nodeFields Node // same fields without the UnmarshalJSON method
+
+type nodeJSON struct {
+	nodeFields
+	Opacity          *float64   `json:"opacity"`
+	StrokeMiterAngle *float64   `json:"strokeMiterAngle"`
+	Children         []nodeJSON `json:"children"`
+}
+
+// Enum fields are never empty in the API, an empty value means the field was missing.
+func (value nodeJSON) node() Node {
+	n := Node(value.nodeFields)
+
+	n.Opacity = floatOr(value.Opacity, 1)
+	n.StrokeMiterAngle = floatOr(value.StrokeMiterAngle, 28.96)
+	if n.Visible == nil {
+		n.Visible = boolPointer(true)
+	}
+
+	n.LayoutMode = cmp.Or(n.LayoutMode, LayoutModeNone)
+	n.LayoutWrap = cmp.Or(n.LayoutWrap, LayoutWrapNoWrap)
+	n.PrimaryAxisSizingMode = cmp.Or(n.PrimaryAxisSizingMode, SizingModeAuto)
+	n.CounterAxisSizingMode = cmp.Or(n.CounterAxisSizingMode, SizingModeAuto)
+	n.PrimaryAxisAlignItems = cmp.Or(n.PrimaryAxisAlignItems, AlignItemsMin)
+	n.CounterAxisAlignItems = cmp.Or(n.CounterAxisAlignItems, AlignItemsMin)
+	n.CounterAxisAlignContent = cmp.Or(n.CounterAxisAlignContent, AlignContentAuto)
+	n.LayoutPositioning = cmp.Or(n.LayoutPositioning, LayoutPositioningAuto)
+	n.OverflowDirection = cmp.Or(n.OverflowDirection, OverflowDirectionNone)
+	n.StrokeCap = cmp.Or(n.StrokeCap, StrokeCapNone)
+	n.StrokeJoin = cmp.Or(n.StrokeJoin, StrokeJoinMitter)
+
+	n.Children = nil
+	if value.Children != nil {
+		n.Children = make([]Node, len(value.Children))
+		for i, child := range value.Children {
+			n.Children[i] = child.node()
+		}
+	}
+
+	return n
+}
+
+func (p *Paint) UnmarshalJSON(data []byte) error {
+	type paint Paint
+	value := paint(DefaultPaint())
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*p = Paint(value)
+	return nil
+}
+
+func (e *Effect) UnmarshalJSON(data []byte) error {
+	type effect Effect
+	value := effect(DefaultEffect())
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*e = Effect(value)
+	return nil
+}
+
+func (l *LayoutGrid) UnmarshalJSON(data []byte) error {
+	type layoutGrid LayoutGrid
+	value := layoutGrid(DefaultLayoutGrid())
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*l = LayoutGrid(value)
+	return nil
+}
+
+func floatOr(value *float64, defaultValue float64) float64 {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+// Each default gets its own pointer, JSON values are written through it.
+func boolPointer(value bool) *bool {
+	return &value
+}
+
+// Deprecated: Node, Paint, Effect and LayoutGrid set their defaults when unmarshalled,
+// SetDefaults does not handle float64 fields or map values.
 func SetDefaults(v interface{}) {
 	setDefaultsRecursive(reflect.ValueOf(v))
 }
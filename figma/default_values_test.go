@@ -1,6 +1,9 @@
 package figma
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
 	"slices"
 	"testing"
 )
@@ -84,3 +87,79 @@ func TestStructDefaults(t *testing.T) {
 		t.Errorf("%+v does not have required defaults", cfga)
 	}
 }
+
+func TestUnmarshalDefaults(t *testing.T) {
+	var node Node
+
+	data := []byte(`{
+		"id": "1:2",
+		"type": "FRAME",
+		"fills": [{"type": "SOLID"}, {"type": "SOLID", "visible": false, "opacity": 0.5}],
+		"effects": [{"type": "DROP_SHADOW"}],
+		"layoutGrids": [{"pattern": "COLUMNS"}],
+		"fillOverrideTable": {"1": {"fills": [{"type": "SOLID"}]}},
+		"children": [{"id": "1:3", "visible": false, "opacity": 0.25, "layoutMode": "VERTICAL"}]
+	}`)
+
+	if err := json.Unmarshal(data, &node); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	want := DefaultNode()
+	if !*node.Visible || node.Opacity != want.Opacity || node.StrokeMiterAngle != want.StrokeMiterAngle ||
+		node.LayoutMode != want.LayoutMode || node.StrokeJoin != want.StrokeJoin {
+		t.Errorf("%+v does not have required defaults", node)
+	}
+
+	if !*node.Fills[0].Visible || node.Fills[0].Opacity != 1 {
+		t.Errorf("%+v does not have required defaults", node.Fills[0])
+	}
+
+	if *node.Fills[1].Visible || node.Fills[1].Opacity != 0.5 {
+		t.Errorf("%+v does not have expected values", node.Fills[1])
+	}
+
+	if !*node.Effects[0].Visible || !*node.LayoutGrids[0].Visible {
+		t.Errorf("%+v, %+v do not have required defaults", node.Effects[0], node.LayoutGrids[0])
+	}
+
+	if override := node.FillOverrideTable[1].Fills[0]; override.Visible == nil || !*override.Visible || override.Opacity != 1 {
+		t.Errorf("%+v does not have required defaults", override)
+	}
+
+	child := node.Children[0]
+	if *child.Visible || child.Opacity != 0.25 || child.LayoutMode != LayoutModeVertical || child.StrokeMiterAngle != 28.96 {
+		t.Errorf("%+v does not have expected values", child)
+	}
+
+	// defaults must not share pointers
+	*node.Fills[0].Visible = false
+	if !*node.Effects[0].Visible || !*DefaultPaint().Visible {
+		t.Errorf("default pointers are shared")
+	}
+}
+
+// The default values must match the `default` tags of every type that has them.
+func TestDefaultsMatchTags(t *testing.T) {
+	defaults := []any{DefaultNode(), DefaultPaint(), DefaultEffect(), DefaultLayoutGrid()}
+
+	for _, value := range defaults {
+		v := reflect.ValueOf(value)
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			tag, ok := field.Tag.Lookup("default")
+			if !ok {
+				continue
+			}
+
+			got := v.Field(i)
+			if got.Kind() == reflect.Ptr {
+				got = got.Elem()
+			}
+
+			if fmt.Sprintf("%v", got.Interface()) != tag {
+				t.Errorf("%v.%v default = %v; want %v", v.Type().Name(), field.Name, got.Interface(), tag)
+			}
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package figma
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DesignToken is a token in the Design Tokens Community Group format.
+type DesignToken struct {
+	Type        string         `json:"$type,omitempty"`
+	Value       any            `json:"$value"`
+	Description string         `json:"$description,omitempty"`
+	Extensions  map[string]any `json:"$extensions,omitempty"`
+}
+
+// DesignTokens are keyed by their path, the group names separated by dots, and are written
+// as nested groups.
+type DesignTokens map[string]DesignToken
+
+func (t DesignTokens) MarshalJSON() ([]byte, error) {
+	groups, err := nestTokens(t)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(groups)
+}
+
+// nestTokens turns the dot separated token paths into nested groups, a token can not
+// also be a group of other tokens.
+func nestTokens[T any](tokens map[string]T) (map[string]any, error) {
+	root := make(map[string]any)
+
+	for _, path := range slices.Sorted(maps.Keys(tokens)) {
+		group := root
+		names := strings.Split(path, ".")
+
+		for i, name := range names[:len(names)-1] {
+			child, ok := group[name].(map[string]any)
+			if !ok {
+				if _, isToken := group[name]; isToken {
+					return nil, fmt.Errorf("design token %v is also a group of %v", strings.Join(names[:i+1], "."), path)
+				}
+				child = make(map[string]any)
+				group[name] = child
+			}
+			group = child
+		}
+
+		name := names[len(names)-1]
+		if _, ok := group[name]; ok {
+			return nil, fmt.Errorf("design token %v is also a group of other tokens", path)
+		}
+		group[name] = tokens[path]
+	}
+
+	return root, nil
+}
+
+// Group types are inherited by the tokens inside them, other group properties are ignored.
+func (t *DesignTokens) UnmarshalJSON(data []byte) error {
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal(data, &root); err != nil {
+		return err
+	}
+
+	*t = make(DesignTokens)
+	return t.unmarshalGroup("", "", root)
+}
+
+func (t DesignTokens) unmarshalGroup(path string, groupType string, group map[string]json.RawMessage) error {
+	if value, ok := group["$type"]; ok {
+		if err := json.Unmarshal(value, &groupType); err != nil {
+			return err
+		}
+	}
+
+	for name, data := range group {
+		if strings.HasPrefix(name, "$") {
+			continue
+		}
+
+		var child map[string]json.RawMessage
+		if err := json.Unmarshal(data, &child); err != nil {
+			return fmt.Errorf("design token %v%v is not a group or token: %w", path, name, err)
+		}
+
+		if _, ok := child["$value"]; !ok {
+			if err := t.unmarshalGroup(path+name+".", groupType, child); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var token DesignToken
+		if err := json.Unmarshal(data, &token); err != nil {
+			return err
+		}
+		if token.Type == "" {
+			token.Type = groupType
+		}
+
+		t[path+name] = token
+	}
+
+	return nil
+}
+
+// DesignTokenName removes the characters token and group names can not have.
+func DesignTokenName(name string) string {
+	name = strings.TrimSpace(strings.NewReplacer(".", "-", "{", "", "}", "").Replace(name))
+	return strings.TrimLeft(name, "$")
+}
+
+// DesignTokenReference returns the path of an alias value, e.g. {color.brand} is color.brand.
+func DesignTokenReference(value any) (string, bool) {
+	reference, ok := value.(string)
+	if !ok || !strings.HasPrefix(reference, "{") || !strings.HasSuffix(reference, "}") {
+		return "", false
+	}
+	return reference[1 : len(reference)-1], true
+}
+
+// DesignTokenColor returns the value of a color token, either a hex string or an sRGB color object.
+func DesignTokenColor(value any) (Color, error) {
+	switch value := value.(type) {
+	case string:
+		return hexColor(value)
+	case map[string]any:
+		color := Color{Alpha: 1.0}
+		if alpha, ok := value["alpha"].(float64); ok {
+			color.Alpha = alpha
+		}
+
+		components, ok := value["components"].([]any)
+		if ok && len(components) == 3 {
+			color.Red, _ = components[0].(float64)
+			color.Green, _ = components[1].(float64)
+			color.Blue, _ = components[2].(float64)
+			return color, nil
+		}
+
+		if hex, ok := value["hex"].(string); ok {
+			alpha := color.Alpha
+			color, err := hexColor(hex)
+			color.Alpha = alpha
+			return color, err
+		}
+	}
+
+	return Color{}, fmt.Errorf("invalid color value %v", value)
+}
+
+// DesignTokenColorValue returns the sRGB color object of a color token.
+func DesignTokenColorValue(c Color) map[string]any {
+	opaque := c
+	opaque.Alpha = 1.0
+
+	return map[string]any{
+		"colorSpace": "srgb",
+		"components": []float64{c.Red, c.Green, c.Blue},
+		"alpha":      c.Alpha,
+		"hex":        opaque.Hex(),
+	}
+}
+
+func hexColor(hex string) (Color, error) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 && len(hex) != 8 {
+		return Color{}, fmt.Errorf("invalid hex color #%v", hex)
+	}
+
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+
+	rgba, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("invalid hex color #%v", hex)
+	}
+
+	return Color{
+		Red:   float64(rgba>>24&0xff) / 255,
+		Green: float64(rgba>>16&0xff) / 255,
+		Blue:  float64(rgba>>8&0xff) / 255,
+		Alpha: float64(rgba&0xff) / 255,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package figma
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDesignTokensJSON(t *testing.T) {
+	tokens := DesignTokens{
+		"color.brand.primary": {Type: "color", Value: "#ff0000", Description: "Main brand color"},
+		"color.brand.accent":  {Type: "color", Value: "{color.brand.primary}"},
+		"space.small":         {Type: "dimension", Value: map[string]any{"value": 4.0, "unit": "px"}},
+	}
+
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	want := `{"color":{"brand":{"accent":{"$type":"color","$value":"{color.brand.primary}"},"primary":{"$type":"color","$value":"#ff0000","$description":"Main brand color"}}},"space":{"small":{"$type":"dimension","$value":{"unit":"px","value":4}}}}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s; want %v", data, want)
+	}
+
+	var decoded DesignTokens
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	if len(decoded) != 3 || decoded["color.brand.primary"].Description != "Main brand color" || decoded["color.brand.accent"].Value != "{color.brand.primary}" {
+		t.Errorf("Unmarshal = %+v", decoded)
+	}
+}
+
+// a token can not be a group, whichever path comes first
+func TestDesignTokensConflict(t *testing.T) {
+	tokens := DesignTokens{
+		"color.brand":         {Type: "color", Value: "#ff0000"},
+		"color.brand.primary": {Type: "color", Value: "#00ff00"},
+	}
+
+	for range 5 {
+		if _, err := json.Marshal(tokens); err == nil || !strings.Contains(err.Error(), "color.brand") {
+			t.Errorf("Marshal error = %v; want color.brand conflict", err)
+		}
+	}
+}
+
+func TestDesignTokensGroupType(t *testing.T) {
+	var tokens DesignTokens
+
+	data := []byte(`{"color": {"$type": "color", "$description": "group", "red": {"$value": "#ff0000"}, "size": {"$type": "dimension", "$value": "4px"}}}`)
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	if tokens["color.red"].Type != "color" || tokens["color.size"].Type != "dimension" || len(tokens) != 2 {
+		t.Errorf("Unmarshal = %+v; want inherited group type", tokens)
+	}
+}
+
+func TestDesignTokenColor(t *testing.T) {
+	tests := []struct {
+		value any
+		want  Color
+	}{
+		{"#ff0000", Color{Red: 1, Alpha: 1}},
+		{"#00ff0080", Color{Green: 1, Alpha: 128.0 / 255}},
+		{map[string]any{"colorSpace": "srgb", "components": []any{0.5, 0.25, 1.0}, "alpha": 0.5}, Color{Red: 0.5, Green: 0.25, Blue: 1, Alpha: 0.5}},
+		{map[string]any{"colorSpace": "srgb", "hex": "#0000ff"}, Color{Blue: 1, Alpha: 1}},
+	}
+
+	for _, test := range tests {
+		ans, err := DesignTokenColor(test.value)
+		if err != nil || ans != test.want {
+			t.Errorf("%+v = %+v, %v; want %+v", test.value, ans, err, test.want)
+		}
+	}
+
+	if _, err := DesignTokenColor("red"); err == nil {
+		t.Errorf("DesignTokenColor(red) error = nil; want invalid color")
+	}
+
+	value := DesignTokenColorValue(Color{Red: 1, Green: 0.5, Alpha: 1})
+	if value["hex"] != "#ff8000" || value["colorSpace"] != "srgb" {
+		t.Errorf("DesignTokenColorValue = %+v", value)
+	}
+}
@@ -2,6 +2,10 @@ package figma
 
 import "fmt"
 
+func (e *Effect) IsVisible() bool {
+	return e.Visible == nil || *e.Visible
+}
+
 func (e *Effect) Value() string {
 	value := ""
 	switch e.Type {
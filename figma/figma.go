@@ -11,6 +11,47 @@ type File struct {
 	Styles        map[string]Style        `json:"styles"`
 }
 
+// Figma file nodes types, returned by the /v1/files/:key/nodes endpoint
+type FileNodes struct {
+	Name         string              `json:"name"`
+	LastModified string              `json:"lastModified"`
+	Version      string              `json:"version"`
+	Nodes        map[string]FileNode `json:"nodes"`
+}
+
+type FileNode struct {
+	Document      Node                    `json:"document"`
+	ComponentSets map[string]ComponentSet `json:"componentSets"`
+	Components    map[string]Component    `json:"components"`
+	Styles        map[string]Style        `json:"styles"`
+}
+
+// Figma file versions types, returned by the /v1/files/:key/versions endpoint
+type FileVersions struct {
+	Versions   []Version  `json:"versions"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type Version struct {
+	ID           string `json:"id"`
+	CreatedAt    string `json:"created_at"`
+	Label        string `json:"label"`
+	Description  string `json:"description"`
+	User         User   `json:"user"`
+	ThumbnailUrl string `json:"thumbnail_url"`
+}
+
+type User struct {
+	ID     string `json:"id"`
+	Handle string `json:"handle"`
+	ImgUrl string `json:"img_url"`
+}
+
+type Pagination struct {
+	PrevPage string `json:"prev_page"`
+	NextPage string `json:"next_page"`
+}
+
 type NodeType string
 
 const (
@@ -101,7 +142,7 @@ type Node struct {
 	FillOverrideTable       map[float64]PaintOverride `json:"fillOverrideTable,omitzero"`
 	IndividualStrokeWeights StrokeWeights             `json:"individualStrokeWeights"`
 	StrokeCap               StrokeCap                 `json:"strokeCap,omitzero" default:"NONE"`
-	StrokeJoin              StrokeJoin                `json:"strokeJoin,omitzero" default:"MITTER"`
+	StrokeJoin              StrokeJoin                `json:"strokeJoin,omitzero" default:"MITER"`
 	StrokeMiterAngle        float64                   `json:"strokeMiterAngle,omitzero" default:"28.96"`
 	StrokeGeometry          []Path                    `json:"strokeGeometry,omitzero"`
 	// BOOLEAN_OPERATION
@@ -173,6 +214,7 @@ const (
 	ImageFormatJPG  ImageFormat = "JPG"
 	ImageFormatPNG              = "PNG"
 	ImageFormateSVG             = "SVG"
+	ImageFormatPDF              = "PDF"
 )
 
 type Constraint struct {
@@ -232,7 +274,7 @@ const (
 	// Darken:
 	BlendModeDarken     = "DARKEN"
 	BlendModeMultiply   = "MULTIPLY"
-	BlendModeLinearBurn = "LINEAR_BURN " // ("Plus darker" in Figma)
+	BlendModeLinearBurn = "LINEAR_BURN" // ("Plus darker" in Figma)
 	BlendModeColorBurn  = "COLOR_BURN"
 
 	// Lighten:
@@ -677,6 +719,39 @@ type Token struct {
 	Value     string
 	Theme     string
 	ClassName string
+	Mode      string // Variable mode name, empty for style tokens
+	Selector  string // Selector the variable is declared under, see CssSelector
+
+	Type        string // Design token type, e.g. color, dimension, shadow or typography
+	Description string
+	Collection  string          // Variable collection name, empty for style tokens
+	Scopes      []VariableScope // Variable scopes, empty for style tokens
+}
+
+// TokenData is the data of the token templates, themes and values are sorted by name.
+type TokenData struct {
+	Themes  []TokenTheme // The default theme is first
+	Classes []TokenClass // Text styles
+	Grids   []TokenClass // Grid styles
+}
+
+type TokenTheme struct {
+	Name     string // default for :root, the kebab case theme or mode name otherwise
+	Selector string
+	Tokens   []TokenValue
+}
+
+type TokenClass struct {
+	Name       string
+	Key        string // Name in camel case
+	Properties []TokenValue
+}
+
+type TokenValue struct {
+	Name   string // Variable or property name without the leading --
+	Key    string // Name in camel case
+	Value  string
+	Quoted string // Value as a JSON string
 }
 
 // Figma Variables types
@@ -692,17 +767,17 @@ type Meta struct {
 }
 
 type Variable struct {
-	ID                   string                 `json:"id"`
-	Name                 string                 `json:"name"`
-	VariableCollectionId string                 `json:"variableCollectionId"`
-	ResolvedType         ResolvedType           `json:"resolvedType"`
-	ValuesByMode         map[string]interface{} `json:"valuesByMode"`
-	Remote               bool                   `json:"remote"`
-	Description          string                 `json:"description"`
-	HiddenFromPublishing bool                   `json:"hiddenFromPublishing"`
-	Scopes               []VariableScope        `json:"scopes"`
-	CodeSyntax           VariableCodeSyntax     `json:"codeSyntax"`
-	DeletedButReferenced bool                   `json:"deletedButReferenced"`
+	ID                   string                   `json:"id"`
+	Name                 string                   `json:"name"`
+	VariableCollectionId string                   `json:"variableCollectionId"`
+	ResolvedType         ResolvedType             `json:"resolvedType"`
+	ValuesByMode         map[string]VariableValue `json:"valuesByMode"`
+	Remote               bool                     `json:"remote"`
+	Description          string                   `json:"description"`
+	HiddenFromPublishing bool                     `json:"hiddenFromPublishing"`
+	Scopes               []VariableScope          `json:"scopes"`
+	CodeSyntax           VariableCodeSyntax       `json:"codeSyntax"`
+	DeletedButReferenced bool                     `json:"deletedButReferenced"`
 }
 
 type VariableCollection struct {
@@ -770,6 +845,22 @@ type VariableCodeSyntax struct {
 	Ios     string `json:"iOS"`
 }
 
+// Figma Images types
+type ImageFills struct {
+	Status float64        `json:"status"`
+	Error  bool           `json:"error"`
+	Meta   ImageFillsMeta `json:"meta"`
+}
+
+type ImageFillsMeta struct {
+	Images map[string]string `json:"images"` // ImageRef to image url
+}
+
+type Images struct {
+	Err    string            `json:"err"`
+	Images map[string]string `json:"images"` // Node ID to image url, empty when the node could not be rendered
+}
+
 type Element struct {
 	Name      string
 	Styles    map[string]string
@@ -0,0 +1,55 @@
+package figma
+
+import (
+	"maps"
+	"slices"
+)
+
+// File merges the requested nodes into a File, so they can be parsed like a full document.
+// Canvas nodes are used as pages, any other node is wrapped in a page of its own.
+func (n *FileNodes) File() File {
+	file := File{
+		Name:          n.Name,
+		LastModified:  n.LastModified,
+		Version:       n.Version,
+		Document:      Node{Type: NodeTypeDocument},
+		ComponentSets: make(map[string]ComponentSet),
+		Components:    make(map[string]Component),
+		Styles:        make(map[string]Style),
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(n.Nodes)) {
+		node := n.Nodes[id]
+		if node.Document.ID == "" {
+			continue // nodes that don't exist are returned as null
+		}
+
+		page := node.Document
+		if page.Type != NodeTypeCanvas {
+			page = Node{
+				Type:     NodeTypeCanvas,
+				ID:       node.Document.ID,
+				Name:     node.Document.Name,
+				Visible:  node.Document.Visible,
+				Children: []Node{node.Document},
+			}
+		}
+
+		file.Document.Children = append(file.Document.Children, page)
+		maps.Copy(file.ComponentSets, node.ComponentSets)
+		maps.Copy(file.Components, node.Components)
+		maps.Copy(file.Styles, node.Styles)
+	}
+
+	return file
+}
+
+// Labelled returns the newest version with the given label, versions are listed newest first.
+func (v *FileVersions) Labelled(label string) (Version, bool) {
+	for _, version := range v.Versions {
+		if version.Label == label {
+			return version, true
+		}
+	}
+	return Version{}, false
+}
@@ -0,0 +1,66 @@
+package figma
+
+import (
+	"testing"
+)
+
+func TestFileNodesFile(t *testing.T) {
+	nodes := FileNodes{
+		Name:    "Design System",
+		Version: "123",
+		Nodes: map[string]FileNode{
+			"1:2": {
+				Document:   Node{ID: "1:2", Name: "Button", Type: NodeTypeComponent},
+				Components: map[string]Component{"1:2": {Name: "Button"}},
+				Styles:     map[string]Style{"S:1": {Name: "primary"}},
+			},
+			"0:1": {
+				Document: Node{ID: "0:1", Name: "Page", Type: NodeTypeCanvas, Children: []Node{{ID: "3:4"}}},
+				Styles:   map[string]Style{"S:2": {Name: "secondary"}},
+			},
+			"9:9": {},
+		},
+	}
+
+	file := nodes.File()
+	if file.Name != "Design System" || file.Version != "123" || file.Document.Type != NodeTypeDocument {
+		t.Errorf("File = %+v", file)
+	}
+
+	pages := file.Document.Children
+	if len(pages) != 2 {
+		t.Fatalf("File pages = %v; want 2", len(pages))
+	}
+
+	if pages[0].ID != "0:1" || pages[0].Children[0].ID != "3:4" {
+		t.Errorf("File canvas page = %+v", pages[0])
+	}
+
+	if pages[1].Type != NodeTypeCanvas || pages[1].Children[0].ID != "1:2" {
+		t.Errorf("File wrapped page = %+v", pages[1])
+	}
+
+	if len(file.Components) != 1 || len(file.Styles) != 2 {
+		t.Errorf("File components = %v, styles = %v", file.Components, file.Styles)
+	}
+}
+
+func TestFileVersionsLabelled(t *testing.T) {
+	versions := FileVersions{
+		Versions: []Version{
+			{ID: "3", Label: ""},
+			{ID: "2", Label: "release"},
+			{ID: "1", Label: "release"},
+		},
+	}
+
+	version, ok := versions.Labelled("release")
+	if !ok || version.ID != "2" {
+		t.Errorf("Labelled = %+v, %v; want version 2", version, ok)
+	}
+
+	_, ok = versions.Labelled("missing")
+	if ok {
+		t.Errorf("Labelled(missing) = true; want false")
+	}
+}
@@ -0,0 +1,71 @@
+package figma
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ImageResolver returns the url for an image fill ImageRef, an empty url skips the image.
+type ImageResolver func(imageRef string) string
+
+// Resolves image refs to files saved in a local folder, named after their ImageRef.
+func LocalImages(dir string, extension string) ImageResolver {
+	return func(imageRef string) string {
+		return path.Join(dir, imageRef+extension)
+	}
+}
+
+// Resolves image refs with the urls returned by the /v1/files/:key/images endpoint.
+func ImageUrls(urls map[string]string) ImageResolver {
+	return func(imageRef string) string {
+		return urls[imageRef]
+	}
+}
+
+// Image returns the css background layer of an image fill. The ImageTransform of cropped images and
+// the ScalingFactor of tiles are not supported, crops fill the element and tiles repeat at the image size.
+// Background images can't be translucent, the paint Opacity is not applied.
+func (p *Paint) Image(images ImageResolver) string {
+	if p.Type != PaintTypeImage || p.ImageRef == "" || images == nil {
+		return ""
+	}
+
+	url := images(p.ImageRef)
+	if url == "" {
+		return ""
+	}
+
+	value := fmt.Sprintf("url(\"%v\")", url)
+	switch p.ScaleMode {
+	case ScaleModeFit:
+		value += " center / contain no-repeat"
+	case ScaleModeTile:
+		value += " top left repeat"
+	case ScaleModeStretch:
+		value += " top left / 100% 100% no-repeat"
+	default:
+		value += " center / cover no-repeat" // Default ScaleModeFill
+	}
+
+	return value
+}
+
+// Image filters range from -1 to 1, css only has equivalents for exposure, contrast and saturation.
+func (f *ImageFilters) Css() string {
+	var value []string
+
+	if f.Exposure != 0.0 {
+		value = append(value, fmt.Sprintf("brightness(%v)", RoundToDecimals(1+f.Exposure, 2)))
+	}
+
+	if f.Contrast != 0.0 {
+		value = append(value, fmt.Sprintf("contrast(%v)", RoundToDecimals(1+f.Contrast, 2)))
+	}
+
+	if f.Saturation != 0.0 {
+		value = append(value, fmt.Sprintf("saturate(%v)", RoundToDecimals(1+f.Saturation, 2)))
+	}
+
+	return strings.Join(value, " ")
+}
@@ -0,0 +1,144 @@
+package figma
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestPaintImage(t *testing.T) {
+	var paint Paint
+	var ans string
+	var want string
+
+	images := ImageUrls(map[string]string{
+		"abc123": "https://example.com/abc123.png",
+	})
+
+	paint = Paint{
+		Type:      PaintTypeImage,
+		Opacity:   1.0,
+		ImageRef:  "abc123",
+		ScaleMode: ScaleModeFill,
+	}
+
+	ans = paint.Image(images)
+	want = "url(\"https://example.com/abc123.png\") center / cover no-repeat"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Image", ans, want)
+	}
+
+	paint.ScaleMode = ScaleModeFit
+	ans = paint.Image(images)
+	want = "url(\"https://example.com/abc123.png\") center / contain no-repeat"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Image", ans, want)
+	}
+
+	paint.ScaleMode = ScaleModeTile
+	ans = paint.Image(images)
+	want = "url(\"https://example.com/abc123.png\") top left repeat"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Image", ans, want)
+	}
+
+	paint.ScaleMode = ScaleModeStretch
+	ans = paint.Image(LocalImages("assets/images", ".png"))
+	want = "url(\"assets/images/abc123.png\") top left / 100% 100% no-repeat"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Image", ans, want)
+	}
+
+	paint.ImageRef = "missing"
+	ans = paint.Image(images)
+	want = ""
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Image", ans, want)
+	}
+
+	ans = paint.Image(nil)
+	want = ""
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Image", ans, want)
+	}
+}
+
+func TestImageFiltersCss(t *testing.T) {
+	var filters ImageFilters
+	var ans string
+	var want string
+
+	filters = ImageFilters{
+		Exposure:   0.2,
+		Contrast:   -0.5,
+		Saturation: -1.0,
+		Tint:       0.3,
+	}
+
+	ans = filters.Css()
+	want = "brightness(1.2) contrast(0.5) saturate(0)"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Css", ans, want)
+	}
+}
+
+func TestNodeCssWithImages(t *testing.T) {
+	var node Node
+	var ans map[string]string
+	var want map[string]string
+
+	isVisible := true
+	parent := Node{
+		Type: NodeTypeFrame,
+	}
+	node = Node{
+		Type:    NodeTypeFrame,
+		Visible: &isVisible,
+		Fills: []Paint{
+			{
+				Type:    PaintTypeSolid,
+				Opacity: 1.0,
+				Color:   Color{Red: 1.0, Green: 1.0, Blue: 1.0, Alpha: 1.0},
+			},
+			{
+				Type:      PaintTypeImage,
+				Opacity:   1.0,
+				ImageRef:  "abc123",
+				ScaleMode: ScaleModeFit,
+				Filters: ImageFilters{
+					Contrast: 0.1,
+				},
+			},
+		},
+	}
+
+	ans = node.CssWith(parent, LocalImages("images", ".png"))
+	want = map[string]string{
+		"background": "url(\"images/abc123.png\") center / contain no-repeat, rgba(255,255,255,1)",
+	}
+	if !maps.Equal(ans, want) {
+		t.Errorf("%+v = %v; want %v", "CssWith", ans, want)
+	}
+
+	ans = node.Css(parent)
+	want = map[string]string{
+		"background": "rgba(255,255,255,1)",
+	}
+	if !maps.Equal(ans, want) {
+		t.Errorf("%+v = %v; want %v", "Css", ans, want)
+	}
+	// the filter only changes the image when it is all the element paints
+	node.Fills = node.Fills[1:]
+	ans = node.CssWith(parent, LocalImages("images", ".png"))
+	want = map[string]string{
+		"background": "url(\"images/abc123.png\") center / contain no-repeat",
+		"filter":     "contrast(1.1)",
+	}
+	if !maps.Equal(ans, want) {
+		t.Errorf("%+v = %v; want %v", "CssWith", ans, want)
+	}
+
+	node.Children = []Node{{Type: NodeTypeText}}
+	if filter, ok := node.ImageFilter(LocalImages("images", ".png")); filter != "contrast(1.1)" || ok {
+		t.Errorf("%+v = %v, %v; want contrast(1.1), false", "ImageFilter", filter, ok)
+	}
+}
@@ -0,0 +1,88 @@
+package figma
+
+import (
+	"fmt"
+	"math"
+)
+
+func (g *LayoutGrid) IsVisible() bool {
+	return g.Visible == nil || *g.Visible
+}
+
+// Css returns the CSS grid rules of a layout grid. Columns and rows stretched to the frame are
+// 1fr tracks with the offset as padding, the others keep their size and are aligned in the frame.
+// Square grids are tracks of the section size in both directions.
+func (g *LayoutGrid) Css() map[string]string {
+	rules := make(map[string]string)
+
+	if !g.IsVisible() {
+		return rules
+	}
+
+	switch g.Pattern {
+	case PatternColumns:
+		g.tracks(rules, "columns", "column-gap", "justify-content", "padding-left", "padding-right")
+	case PatternRows:
+		g.tracks(rules, "rows", "row-gap", "align-content", "padding-top", "padding-bottom")
+	case PatternGrid:
+		if g.SectionSize != 0.0 {
+			rules["grid-template-columns"] = fmt.Sprintf("repeat(auto-fill, %v)", pixels(g.SectionSize))
+			rules["grid-auto-rows"] = pixels(g.SectionSize)
+		}
+	}
+
+	return rules
+}
+
+func (g *LayoutGrid) tracks(rules map[string]string, axis string, gap string, align string, start string, end string) {
+	count := "auto-fill" // figma auto count, as many tracks as fit
+	if g.Count > 0 && !math.IsInf(g.Count, 0) {
+		count = fmt.Sprint(g.Count)
+	}
+
+	track := pixels(g.SectionSize)
+	switch g.Alignment {
+	case AlignmentStretch:
+		track = "1fr"
+		if count == "auto-fill" {
+			track = fmt.Sprintf("minmax(%v, 1fr)", pixels(g.SectionSize))
+		}
+		if g.Offset != 0.0 {
+			rules[start] = pixels(g.Offset)
+			rules[end] = pixels(g.Offset)
+		}
+	case Alignmentcenter:
+		rules[align] = "center"
+	default:
+		rules[align] = "start"
+		if g.Offset != 0.0 {
+			rules[start] = pixels(g.Offset)
+		}
+	}
+
+	rules["grid-template-"+axis] = fmt.Sprintf("repeat(%v, %v)", count, track)
+
+	if g.GutterSize != 0.0 {
+		rules[gap] = pixels(g.GutterSize)
+	}
+}
+
+// Grid returns the CSS grid rules of the visible layout grids, square grids do not replace
+// the tracks of column and row grids.
+func (n *Node) Grid() map[string]string {
+	rules := make(map[string]string)
+
+	for _, grid := range n.LayoutGrids {
+		for key, value := range grid.Css() {
+			if _, ok := rules[key]; !ok || grid.Pattern != PatternGrid {
+				rules[key] = value
+			}
+		}
+	}
+
+	if len(rules) > 0 {
+		rules["display"] = "grid"
+	}
+
+	return rules
+}
@@ -0,0 +1,73 @@
+package figma
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestLayoutGridCss(t *testing.T) {
+	hidden := false
+
+	tests := []struct {
+		grid LayoutGrid
+		want map[string]string
+	}{
+		{
+			LayoutGrid{Pattern: PatternColumns, Alignment: AlignmentStretch, Count: 12, GutterSize: 24, Offset: 32, SectionSize: 10},
+			map[string]string{"grid-template-columns": "repeat(12, 1fr)", "column-gap": "24px", "padding-left": "32px", "padding-right": "32px"},
+		},
+		{
+			LayoutGrid{Pattern: PatternColumns, Alignment: AlignmentMin, Count: 4, GutterSize: 16, Offset: 8, SectionSize: 80},
+			map[string]string{"grid-template-columns": "repeat(4, 80px)", "column-gap": "16px", "justify-content": "start", "padding-left": "8px"},
+		},
+		{
+			LayoutGrid{Pattern: PatternRows, Alignment: Alignmentcenter, Count: 3, GutterSize: 8, Offset: 20, SectionSize: 64},
+			map[string]string{"grid-template-rows": "repeat(3, 64px)", "row-gap": "8px", "align-content": "center"},
+		},
+		{
+			LayoutGrid{Pattern: PatternColumns, Alignment: AlignmentStretch, Count: -1, SectionSize: 120},
+			map[string]string{"grid-template-columns": "repeat(auto-fill, minmax(120px, 1fr))"},
+		},
+		{
+			LayoutGrid{Pattern: PatternGrid, SectionSize: 8},
+			map[string]string{"grid-template-columns": "repeat(auto-fill, 8px)", "grid-auto-rows": "8px"},
+		},
+		{
+			LayoutGrid{Pattern: PatternGrid, SectionSize: 8, Visible: &hidden},
+			map[string]string{},
+		},
+	}
+
+	for _, test := range tests {
+		ans := test.grid.Css()
+		if !maps.Equal(ans, test.want) {
+			t.Errorf("%+v = %v; want %v", test.grid, ans, test.want)
+		}
+	}
+}
+
+func TestNodeGrid(t *testing.T) {
+	node := Node{
+		Type: NodeTypeFrame,
+		LayoutGrids: []LayoutGrid{
+			{Pattern: PatternGrid, SectionSize: 8},
+			{Pattern: PatternColumns, Alignment: AlignmentStretch, Count: 2, GutterSize: 10},
+		},
+	}
+
+	ans := node.Grid()
+	want := map[string]string{
+		"display":               "grid",
+		"grid-template-columns": "repeat(2, 1fr)",
+		"grid-auto-rows":        "8px",
+		"column-gap":            "10px",
+	}
+	if !maps.Equal(ans, want) {
+		t.Errorf("%+v = %v; want %v", "Grid", ans, want)
+	}
+
+	// layout grids are a guide, Css does not lay out the children in them
+	if ans := node.Css(Node{}); ans["display"] != "" || ans["grid-template-columns"] != "" {
+		t.Errorf("%+v = %v; want no grid rules", "Css", ans)
+	}
+}
@@ -30,6 +30,10 @@ func (n *Node) IsVector() bool {
 	return n.Type == NodeTypeVector
 }
 
+func (n *Node) IsVisible() bool {
+	return n.Visible == nil || *n.Visible
+}
+
 func (n *Node) IsAutoLayout() bool {
 	return n.LayoutMode == LayoutModeHorizontal || n.LayoutMode == LayoutModeVertical
 }
@@ -43,48 +47,137 @@ func (n *Node) IsFrame() bool {
 }
 
 func (n *Node) Background() string {
-	color := ""
+	return n.BackgroundWith(nil)
+}
+
+// Figma paints are ordered bottom to top, css backgrounds top to bottom.
+// Image fills are only added when there is a resolver for their url.
+func (n *Node) BackgroundWith(images ImageResolver) string {
+	fills := n.backgroundFills(images)
+	var layers []string
+
+	for i := len(fills) - 1; i >= 0; i-- {
+		fill := fills[i]
+		value := fill.Layer(images)
+
+		// only the bottom layer of a css background can be a plain colour
+		if fill.Type == PaintTypeSolid && i > 0 {
+			value = fmt.Sprintf("linear-gradient(%v, %v)", value, value)
+		}
+
+		layers = append(layers, value)
+	}
+
+	return strings.Join(layers, ", ")
+}
+
+func (n *Node) BackgroundBlendMode(images ImageResolver) string {
+	fills := n.backgroundFills(images)
+	var modes []string
+	blended := false
+
+	for i := len(fills) - 1; i >= 0; i-- {
+		mode := fills[i].BlendMode.Css()
+		if mode != "normal" {
+			blended = true
+		}
+
+		// a diamond gradient takes one background layer per quadrant
+		layers := 1
+		if fills[i].Type == PaintTypeGradientDiamond {
+			layers = 4
+		}
+		for range layers {
+			modes = append(modes, mode)
+		}
+	}
+
+	if !blended {
+		return ""
+	}
+
+	return strings.Join(modes, ", ")
+}
+
+// ImageFilter returns the filters of the top image fill and whether CssWith uses them. A css filter
+// applies to the whole element, so it is only used when the image is all the element paints: no
+// children, other fills, strokes or shadows.
+func (n *Node) ImageFilter(images ImageResolver) (string, bool) {
+	filter := ""
+	fills := n.backgroundFills(images)
+	for _, fill := range fills {
+		if fill.Type == PaintTypeImage {
+			filter = fill.Filters.Css()
+		}
+	}
+
+	alone := len(fills) == 1 && len(n.Children) == 0 && n.BorderColor() == "" && n.BorderImage() == "" && n.BoxShadow() == ""
+	return filter, filter != "" && alone
+}
+
+func (n *Node) backgroundFills(images ImageResolver) []Paint {
+	var fills []Paint
+
 	for _, fill := range n.Fills {
-		switch fill.Type {
-		case PaintTypeSolid:
-			color = fill.Color.Rgba()
-		case PaintTypeGradientLinear:
-			color = ""
-		case PaintTypeGradientAngular:
-			color = ""
-		case PaintTypeGradientRadial:
-			color = ""
-		case PaintTypeGradientDiamond:
-			color = ""
+		if fill.IsVisible() && fill.Layer(images) != "" {
+			fills = append(fills, fill)
 		}
 	}
-	return color
+
+	return fills
 }
 
+// BorderColor is the colour of the top solid stroke, gradient strokes are not a valid
+// border colour and are painted by BorderImage.
 func (n *Node) BorderColor() string {
 	color := ""
-	for _, fill := range n.Strokes {
-		switch fill.Type {
-		case PaintTypeSolid:
-			color = fill.Color.Rgba()
-			// case PaintTypeGradientLinear:
-			// 	background = ""
-			// case PaintTypeGradientAngular:
-			// 	background = ""
-			// case PaintTypeGradientRadial:
-			// 	background = ""
-			// case PaintTypeGradientDiamond:
-			// 	background = ""
+	for _, stroke := range n.Strokes {
+		value := stroke.borderValue()
+		if !stroke.IsVisible() || value == "" {
+			continue
+		}
+
+		color = ""
+		if stroke.Type == PaintTypeSolid {
+			color = value
 		}
 	}
 	return color
 }
 
+// Gradient strokes can't be used as a border colour, they are painted with border-image instead.
+func (n *Node) BorderImage() string {
+	image := ""
+	for _, stroke := range n.Strokes {
+		value := stroke.borderValue()
+		if !stroke.IsVisible() || value == "" {
+			continue
+		}
+
+		switch stroke.Type {
+		case PaintTypeSolid:
+			image = ""
+		case PaintTypeGradientLinear, PaintTypeGradientAngular, PaintTypeGradientRadial, PaintTypeGradientDiamond:
+			image = fmt.Sprintf("%v 1", value)
+		}
+	}
+	return image
+}
+
+// borderValue is the value of a stroke, empty when it can't be painted, e.g. a gradient without enough handles.
+func (p *Paint) borderValue() string {
+	if p.Type == PaintTypeGradientDiamond {
+		// border-image takes a single image, so diamond strokes fall back to a radial gradient
+		return p.RadialGradient()
+	}
+	return p.Value()
+}
+
 func (n *Node) BoxShadow() string {
 	var value []string
 
 	for _, effect := range n.Effects {
-		if *effect.Visible {
+		if effect.IsVisible() {
 			value = append(value, effect.Value())
 		}
 	}
@@ -92,35 +185,23 @@ func (n *Node) BoxShadow() string {
 	return strings.Join(value, ", ")
 }
 
+// Font returns the text style properties as "property: value;" rules separated by "|".
 func (n *Node) Font() string {
 	var value []string
-
-	if n.Style.FontFamily != "" {
-		value = append(value, fmt.Sprintf("font-family: %v;", n.Style.FontFamily))
-	}
-
-	if n.Style.FontSize != 0.0 {
-		value = append(value, fmt.Sprintf("font-size: %vpx;", int(n.Style.FontSize)))
-	}
-
-	if n.Style.FontWeight != 0.0 {
-		value = append(value, fmt.Sprintf("font-weight: %v;", int(n.Style.FontWeight)))
-	}
-
-	if n.Style.LineHeightPx != 0.0 {
-		value = append(value, fmt.Sprintf("line-height: %vpx;", int(n.Style.LineHeightPx)))
-	}
-
-	if n.Style.LetterSpacing != 0.0 {
-		value = append(value, fmt.Sprintf("letter-spacing: %vpx;", int(n.Style.LetterSpacing)))
+	for _, property := range n.Style.Properties() {
+		value = append(value, fmt.Sprintf("%v: %v;", property.Name, property.Value))
 	}
 	return strings.Join(value, "|")
 }
 
 func (n *Node) Css(parent Node) map[string]string {
+	return n.CssWith(parent, nil)
+}
+
+func (n *Node) CssWith(parent Node, images ImageResolver) map[string]string {
 	rules := make(map[string]string)
 
-	if !*n.Visible {
+	if !n.IsVisible() {
 		rules["display"] = "none"
 	}
 
@@ -133,7 +214,7 @@ func (n *Node) Css(parent Node) map[string]string {
 	}
 
 	if n.IsAutoLayout() {
-		if *n.Visible {
+		if n.IsVisible() {
 			rules["display"] = "flex"
 		}
 		if n.LayoutWrap == LayoutWrapWrap {
@@ -169,8 +250,8 @@ func (n *Node) Css(parent Node) map[string]string {
 		rules[key] = value
 	}
 
-	if n.Background() != "" {
-		background := n.Background()
+	if n.BackgroundWith(images) != "" {
+		background := n.BackgroundWith(images)
 
 		// TODO: Get token for background if exixts
 		// if n.Styles["fill"] != "" {
@@ -180,6 +261,10 @@ func (n *Node) Css(parent Node) map[string]string {
 		rules["background"] = background
 	}
 
+	if n.BackgroundBlendMode(images) != "" {
+		rules["background-blend-mode"] = n.BackgroundBlendMode(images)
+	}
+
 	if n.BoxShadow() != "" {
 		boxShadow := n.BoxShadow()
 
@@ -191,8 +276,15 @@ func (n *Node) Css(parent Node) map[string]string {
 		rules["box-shadow"] = boxShadow
 	}
 
+	var filters []string
+	if filter, ok := n.ImageFilter(images); ok {
+		filters = append(filters, filter)
+	}
 	if n.Blur() != "" {
-		rules["filter"] = n.Blur()
+		filters = append(filters, n.Blur())
+	}
+	if len(filters) > 0 {
+		rules["filter"] = strings.Join(filters, " ")
 	}
 
 	if n.BackgroundBlur() != "" {
@@ -361,8 +453,14 @@ func (n *Node) Border() map[string]string {
 
 	style := n.BorderStyle()
 	color := n.BorderColor()
+	image := n.BorderImage()
 	width := ""
 
+	if image != "" {
+		// the border colour is painted over by border-image
+		color = "transparent"
+	}
+
 	if n.StrokeWeight != 0.0 {
 		width = fmt.Sprintf("%vpx", int(n.StrokeWeight))
 	}
@@ -384,6 +482,10 @@ func (n *Node) Border() map[string]string {
 		}
 	}
 
+	if image != "" && len(rules) > 0 {
+		rules["border-image"] = image
+	}
+
 	return rules
 }
 
@@ -397,7 +499,7 @@ func (n *Node) BorderStyle() string {
 func (n *Node) Blur() string {
 	blur := ""
 	for _, effect := range n.Effects {
-		if *effect.Visible && effect.Type == EffectTypeLayerBlur {
+		if effect.IsVisible() && effect.Type == EffectTypeLayerBlur {
 			blur = effect.Value()
 		}
 	}
@@ -407,7 +509,7 @@ func (n *Node) Blur() string {
 func (n *Node) BackgroundBlur() string {
 	blur := ""
 	for _, effect := range n.Effects {
-		if *effect.Visible && effect.Type == EffectTypeBackgroundBlur {
+		if effect.IsVisible() && effect.Type == EffectTypeBackgroundBlur {
 			blur = effect.Value()
 		}
 	}
@@ -476,7 +578,15 @@ func (n *Node) TextCss() map[string]string {
 	}
 
 	if n.Background() != "" {
-		rules["color"] = n.Background()
+		if fills := n.backgroundFills(nil); len(fills) > 1 || fills[0].Type != PaintTypeSolid {
+			// gradients can't be a text colour, paint the background and clip it to the text instead
+			rules["background"] = n.Background()
+			rules["background-clip"] = "text"
+			rules["-webkit-background-clip"] = "text"
+			rules["color"] = "transparent"
+		} else {
+			rules["color"] = n.Background()
+		}
 	}
 
 	if n.MinWidth != 0.0 {
@@ -14,7 +14,8 @@ func TestNodeBackground(t *testing.T) {
 		Type: NodeTypeFrame,
 		Fills: []Paint{
 			{
-				Type: PaintTypeSolid,
+				Type:    PaintTypeSolid,
+				Opacity: 1.0,
 				Color: Color{
 					Red:   0.1,
 					Green: 0.2,
@@ -30,6 +31,143 @@ func TestNodeBackground(t *testing.T) {
 	if ans != want {
 		t.Errorf("%+v = %v; want %v", "Background", ans, want)
 	}
+
+	node.Fills[0].Opacity = 0.0
+	ans = node.Background()
+	want = "rgba(25,51,76,0)"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Background with zero opacity", ans, want)
+	}
+
+	stops := []ColorStop{
+		{
+			Position: 0.0,
+			Color:    Color{Red: 1.0, Green: 0.0, Blue: 0.0, Alpha: 1.0},
+		},
+		{
+			Position: 1.0,
+			Color:    Color{Red: 0.0, Green: 0.0, Blue: 1.0, Alpha: 1.0},
+		},
+	}
+
+	node.Fills = []Paint{
+		{
+			Type:    PaintTypeGradientLinear,
+			Opacity: 1.0,
+			GradientHandlePositions: []Vector{
+				{X: 0.5, Y: 0.0},
+				{X: 0.5, Y: 1.0},
+				{X: 0.0, Y: 0.0},
+			},
+			GradientStops: stops,
+		},
+	}
+	ans = node.Background()
+	want = "linear-gradient(180deg, rgba(255,0,0,1) 0%, rgba(0,0,255,1) 100%)"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Background", ans, want)
+	}
+
+	node.Fills[0].GradientHandlePositions = []Vector{
+		{X: 0.0, Y: 0.5},
+		{X: 0.5, Y: 0.5},
+		{X: 0.0, Y: 0.0},
+	}
+	ans = node.Background()
+	want = "linear-gradient(90deg, rgba(255,0,0,1) 0%, rgba(0,0,255,1) 50%)"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Background", ans, want)
+	}
+
+	node.Fills[0].GradientHandlePositions = []Vector{
+		{X: 0.0, Y: 0.0},
+		{X: 1.0, Y: 1.0},
+		{X: 0.0, Y: 0.0},
+	}
+	ans = node.Background()
+	want = "linear-gradient(135deg, rgba(255,0,0,1) 0%, rgba(0,0,255,1) 100%)"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Background", ans, want)
+	}
+
+	node.Fills[0].Type = PaintTypeGradientRadial
+	node.Fills[0].GradientHandlePositions = []Vector{
+		{X: 0.5, Y: 0.5},
+		{X: 1.0, Y: 0.5},
+		{X: 0.5, Y: 1.0},
+	}
+	ans = node.Background()
+	want = "radial-gradient(ellipse 50% 50% at 50% 50%, rgba(255,0,0,1) 0%, rgba(0,0,255,1) 100%)"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Background", ans, want)
+	}
+
+	node.Fills[0].Type = PaintTypeGradientAngular
+	ans = node.Background()
+	want = "conic-gradient(from 90deg at 50% 50%, rgba(255,0,0,1) 0%, rgba(0,0,255,1) 100%)"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Background", ans, want)
+	}
+
+	node.Fills[0].Type = PaintTypeGradientDiamond
+	ans = node.Background()
+	want = "linear-gradient(to bottom right, rgba(255,0,0,1) 0%, rgba(0,0,255,1) 50%) bottom right / 50% 50% no-repeat, " +
+		"linear-gradient(to bottom left, rgba(255,0,0,1) 0%, rgba(0,0,255,1) 50%) bottom left / 50% 50% no-repeat, " +
+		"linear-gradient(to top left, rgba(255,0,0,1) 0%, rgba(0,0,255,1) 50%) top left / 50% 50% no-repeat, " +
+		"linear-gradient(to top right, rgba(255,0,0,1) 0%, rgba(0,0,255,1) 50%) top right / 50% 50% no-repeat"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Background", ans, want)
+	}
+}
+
+func TestNodeBackgroundLayers(t *testing.T) {
+	var node Node
+	var ans string
+	var want string
+
+	isVisible := false
+
+	node = Node{
+		Type: NodeTypeFrame,
+		Fills: []Paint{
+			{
+				Type:    PaintTypeSolid,
+				Opacity: 1.0,
+				Color:   Color{Red: 1.0, Green: 1.0, Blue: 1.0, Alpha: 1.0},
+			},
+			{
+				Type:    PaintTypeSolid,
+				Opacity: 1.0,
+				Visible: &isVisible,
+				Color:   Color{Red: 1.0, Green: 0.0, Blue: 0.0, Alpha: 1.0},
+			},
+			{
+				Type:      PaintTypeSolid,
+				Opacity:   0.5,
+				BlendMode: BlendModeMultiply,
+				Color:     Color{Red: 0.0, Green: 0.0, Blue: 0.0, Alpha: 1.0},
+			},
+		},
+	}
+
+	ans = node.Background()
+	want = "linear-gradient(rgba(0,0,0,0.5), rgba(0,0,0,0.5)), rgba(255,255,255,1)"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Background", ans, want)
+	}
+
+	ans = node.BackgroundBlendMode(nil)
+	want = "multiply, normal"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "BackgroundBlendMode", ans, want)
+	}
+
+	node.Fills[2].BlendMode = BlendModeNormal
+	ans = node.BackgroundBlendMode(nil)
+	want = ""
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "BackgroundBlendMode", ans, want)
+	}
 }
 
 func TestNodeBorderColor(t *testing.T) {
@@ -41,7 +179,8 @@ func TestNodeBorderColor(t *testing.T) {
 		Type: NodeTypeFrame,
 		Strokes: []Paint{
 			{
-				Type: PaintTypeSolid,
+				Type:    PaintTypeSolid,
+				Opacity: 1.0,
 				Color: Color{
 					Red:   0.1,
 					Green: 0.2,
@@ -57,6 +196,103 @@ func TestNodeBorderColor(t *testing.T) {
 	if ans != want {
 		t.Errorf("%+v = %v; want %v", "BorderColor", ans, want)
 	}
+
+	node.Strokes = []Paint{
+		{
+			Type:    PaintTypeGradientLinear,
+			Opacity: 1.0,
+			GradientHandlePositions: []Vector{
+				{X: 0.5, Y: 0.0},
+				{X: 0.5, Y: 1.0},
+				{X: 0.0, Y: 0.0},
+			},
+			GradientStops: []ColorStop{
+				{Position: 0.0, Color: Color{Red: 1.0, Alpha: 1.0}},
+				{Position: 1.0, Color: Color{Blue: 1.0, Alpha: 1.0}},
+			},
+		},
+	}
+	ans = node.BorderColor()
+	want = ""
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "BorderColor", ans, want)
+	}
+}
+
+func TestNodeBorderImage(t *testing.T) {
+	var node Node
+	var ans map[string]string
+	var want map[string]string
+
+	node = Node{
+		Type: NodeTypeFrame,
+		Strokes: []Paint{
+			{
+				Type:    PaintTypeGradientLinear,
+				Opacity: 1.0,
+				GradientHandlePositions: []Vector{
+					{X: 0.5, Y: 0.0},
+					{X: 0.5, Y: 1.0},
+					{X: 0.0, Y: 0.0},
+				},
+				GradientStops: []ColorStop{
+					{Position: 0.0, Color: Color{Red: 1.0, Alpha: 1.0}},
+					{Position: 1.0, Color: Color{Blue: 1.0, Alpha: 1.0}},
+				},
+			},
+		},
+		StrokeWeight: 2.0,
+	}
+
+	ans = node.Border()
+	want = map[string]string{
+		"border":       "2px solid transparent",
+		"border-image": "linear-gradient(180deg, rgba(255,0,0,1) 0%, rgba(0,0,255,1) 100%) 1",
+	}
+	if !maps.Equal(ans, want) {
+		t.Errorf("%+v = %v; want %v", "Border", ans, want)
+	}
+
+	node.StrokeWeight = 0.0
+	ans = node.Border()
+	want = map[string]string{}
+	if !maps.Equal(ans, want) {
+		t.Errorf("%+v = %v; want %v", "Border", ans, want)
+	}
+
+	// gradients without enough handles have no value and are skipped
+	node.StrokeWeight = 2.0
+	gradients := map[PaintType][]Vector{
+		PaintTypeGradientLinear:  {{X: 0.5, Y: 0.0}},
+		PaintTypeGradientDiamond: {{X: 0.5, Y: 0.5}, {X: 1.0, Y: 0.5}},
+	}
+	for paintType, handles := range gradients {
+		node.Strokes = []Paint{
+			{Type: PaintTypeSolid, Opacity: 1.0, Color: Color{Green: 1.0, Alpha: 1.0}},
+			{
+				Type:                    paintType,
+				Opacity:                 1.0,
+				GradientHandlePositions: handles,
+				GradientStops: []ColorStop{
+					{Position: 0.0, Color: Color{Red: 1.0, Alpha: 1.0}},
+					{Position: 1.0, Color: Color{Blue: 1.0, Alpha: 1.0}},
+				},
+			},
+		}
+
+		ans = node.Border()
+		want = map[string]string{"border": "2px solid rgba(0,255,0,1)"}
+		if !maps.Equal(ans, want) {
+			t.Errorf("%+v = %v; want %v", paintType, ans, want)
+		}
+
+		node.Strokes = node.Strokes[1:]
+		ans = node.Border()
+		want = map[string]string{}
+		if !maps.Equal(ans, want) {
+			t.Errorf("%+v = %v; want %v", paintType, ans, want)
+		}
+	}
 }
 
 func TestNodeBoxShadow(t *testing.T) {
@@ -474,7 +710,8 @@ func TestNodeBorder(t *testing.T) {
 		Type: NodeTypeFrame,
 		Strokes: []Paint{
 			{
-				Type: PaintTypeSolid,
+				Type:    PaintTypeSolid,
+				Opacity: 1.0,
 				Color: Color{
 					Red:   0.1,
 					Green: 0.2,
@@ -510,7 +747,8 @@ func TestNodeindividualBorders(t *testing.T) {
 		Type: NodeTypeFrame,
 		Strokes: []Paint{
 			{
-				Type: PaintTypeSolid,
+				Type:    PaintTypeSolid,
+				Opacity: 1.0,
 				Color: Color{
 					Red:   0.1,
 					Green: 0.2,
@@ -651,7 +889,8 @@ func TestNodeCss(t *testing.T) {
 		Rotation: 0.7853982,
 		Fills: []Paint{
 			{
-				Type: PaintTypeSolid,
+				Type:    PaintTypeSolid,
+				Opacity: 1.0,
 				Color: Color{
 					Red:   1.0,
 					Green: 1.0,
@@ -662,7 +901,8 @@ func TestNodeCss(t *testing.T) {
 		},
 		Strokes: []Paint{
 			{
-				Type: PaintTypeSolid,
+				Type:    PaintTypeSolid,
+				Opacity: 1.0,
 				Color: Color{
 					Red:   0.0,
 					Green: 0.0,
@@ -0,0 +1,185 @@
+package figma
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+func (p *Paint) IsGradient() bool {
+	return p.Type == PaintTypeGradientLinear ||
+		p.Type == PaintTypeGradientRadial ||
+		p.Type == PaintTypeGradientAngular ||
+		p.Type == PaintTypeGradientDiamond
+}
+
+func (p *Paint) IsVisible() bool {
+	return p.Visible == nil || *p.Visible
+}
+
+func (p *Paint) Value() string {
+	value := ""
+	switch p.Type {
+	case PaintTypeSolid:
+		color := p.color(p.Color)
+		value = color.Rgba()
+	case PaintTypeGradientLinear:
+		value = p.LinearGradient()
+	case PaintTypeGradientRadial:
+		value = p.RadialGradient()
+	case PaintTypeGradientAngular:
+		value = p.ConicGradient()
+	case PaintTypeGradientDiamond:
+		value = p.DiamondGradient()
+	}
+	return value
+}
+
+// Layer is the css background layer for the paint, images need a resolver for their url.
+func (p *Paint) Layer(images ImageResolver) string {
+	if p.Type == PaintTypeImage {
+		return p.Image(images)
+	}
+	return p.Value()
+}
+
+func (p *Paint) LinearGradient() string {
+	if len(p.GradientHandlePositions) < 2 {
+		return ""
+	}
+
+	start := p.GradientHandlePositions[0]
+	end := p.GradientHandlePositions[1]
+	angle := handleAngle(start, end)
+
+	// Figma stops are relative to the handles, css stops are relative to a gradient line that
+	// goes through the center of the box and is long enough to reach its corners.
+	rad := angle * math.Pi / 180
+	sin, cos := math.Sin(rad), math.Cos(rad)
+	length := math.Abs(sin) + math.Abs(cos)
+	project := func(v Vector) float64 {
+		return ((v.X-0.5)*sin-(v.Y-0.5)*cos)/length + 0.5
+	}
+	from := project(start)
+	to := project(end)
+
+	stops := p.colorStops(func(position float64) float64 {
+		return from + position*(to-from)
+	})
+
+	return fmt.Sprintf("linear-gradient(%vdeg, %v)", RoundToDecimals(angle, 2), stops)
+}
+
+func (p *Paint) RadialGradient() string {
+	if len(p.GradientHandlePositions) < 3 {
+		return ""
+	}
+
+	center := p.GradientHandlePositions[0]
+	width := handleDistance(center, p.GradientHandlePositions[1])
+	height := handleDistance(center, p.GradientHandlePositions[2])
+	stops := p.colorStops(func(position float64) float64 { return position })
+
+	return fmt.Sprintf("radial-gradient(ellipse %v%% %v%% at %v%% %v%%, %v)", percentage(width), percentage(height), percentage(center.X), percentage(center.Y), stops)
+}
+
+func (p *Paint) ConicGradient() string {
+	if len(p.GradientHandlePositions) < 2 {
+		return ""
+	}
+
+	center := p.GradientHandlePositions[0]
+	angle := handleAngle(center, p.GradientHandlePositions[1])
+	stops := p.colorStops(func(position float64) float64 { return position })
+
+	return fmt.Sprintf("conic-gradient(from %vdeg at %v%% %v%%, %v)", RoundToDecimals(angle, 2), percentage(center.X), percentage(center.Y), stops)
+}
+
+// CSS has no diamond gradient, it is approximated with one linear gradient per quadrant of the box,
+// a linear gradient to a corner has its colour lines going through the other two corners which draws the diamond.
+func (p *Paint) DiamondGradient() string {
+	if len(p.GradientStops) == 0 {
+		return ""
+	}
+
+	stops := p.colorStops(func(position float64) float64 { return position / 2 })
+	quadrants := []string{"bottom right", "bottom left", "top left", "top right"}
+
+	var layers []string
+	for _, quadrant := range quadrants {
+		layers = append(layers, fmt.Sprintf("linear-gradient(to %v, %v) %v / 50%% 50%% no-repeat", quadrant, stops, quadrant))
+	}
+
+	return strings.Join(layers, ", ")
+}
+
+func (p *Paint) colorStops(position func(float64) float64) string {
+	var stops []string
+
+	for _, stop := range p.GradientStops {
+		color := p.color(stop.Color)
+		stops = append(stops, fmt.Sprintf("%v %v%%", color.Rgba(), percentage(position(stop.Position))))
+	}
+
+	return strings.Join(stops, ", ")
+}
+
+// Paint opacity is applied on top of the colour alpha, it defaults to 1 when unmarshalled.
+func (p *Paint) color(c Color) Color {
+	c.Alpha *= p.Opacity
+	return c
+}
+
+// Angle between two handles in css degrees, 0deg points up and grows clockwise.
+func handleAngle(from Vector, to Vector) float64 {
+	angle := math.Atan2(to.X-from.X, from.Y-to.Y) * 180 / math.Pi
+	if angle < 0 {
+		angle += 360
+	}
+	return angle
+}
+
+func handleDistance(from Vector, to Vector) float64 {
+	return math.Hypot(to.X-from.X, to.Y-from.Y)
+}
+
+func percentage(value float64) float64 {
+	return RoundToDecimals(value*100, 2)
+}
+
+func (b BlendMode) Css() string {
+	mode := "normal"
+	switch b {
+	case BlendModeDarken:
+		mode = "darken"
+	case BlendModeMultiply:
+		mode = "multiply"
+	case BlendModeLinearBurn, BlendModeColorBurn:
+		mode = "color-burn" // css has no linear burn
+	case BlendModeLighten:
+		mode = "lighten"
+	case BlendModeScreen:
+		mode = "screen"
+	case BlendModeLinearDodge, BlendModeColorDodge:
+		mode = "color-dodge" // css has no linear dodge for backgrounds
+	case BlendModeOverlay:
+		mode = "overlay"
+	case BlendModeSoftLight:
+		mode = "soft-light"
+	case BlendModeHardLight:
+		mode = "hard-light"
+	case BlendModeDifference:
+		mode = "difference"
+	case BlendModeExclusion:
+		mode = "exclusion"
+	case BlendModeHue:
+		mode = "hue"
+	case BlendModeSaturation:
+		mode = "saturation"
+	case BlendModeColor:
+		mode = "color"
+	case BlendModeLuminosity:
+		mode = "luminosity"
+	}
+	return mode
+}
@@ -0,0 +1,57 @@
+package figma
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// StyleDictionaryToken is a token in the Style Dictionary source format.
+type StyleDictionaryToken struct {
+	Value      any            `json:"value"`
+	Type       string         `json:"type,omitempty"`
+	Comment    string         `json:"comment,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// StyleDictionary tokens are keyed by their dot separated path and are written as nested groups.
+type StyleDictionary map[string]StyleDictionaryToken
+
+func (s StyleDictionary) MarshalJSON() ([]byte, error) {
+	groups, err := nestTokens(s)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(groups)
+}
+
+var styleDictionaryAttributes = []string{"category", "type", "item", "subitem", "state"}
+
+// StyleDictionaryAttributes returns the category, type, item, subitem and state of a path,
+// the same attributes the Style Dictionary attribute/cti transform sets.
+func StyleDictionaryAttributes(path string) map[string]any {
+	attributes := make(map[string]any)
+	for i, name := range strings.Split(path, ".") {
+		if i < len(styleDictionaryAttributes) {
+			attributes[styleDictionaryAttributes[i]] = name
+		}
+	}
+	return attributes
+}
+
+// StyleDictionaryCategory returns the Style Dictionary category of a design token type. Numbers
+// are not sizes, the size transforms would give them a unit.
+func StyleDictionaryCategory(tokenType string) string {
+	switch tokenType {
+	case "dimension":
+		return "size"
+	case "string":
+		return "content"
+	case "typography", "fontFamily", "fontWeight":
+		return "font"
+	case "duration":
+		return "time"
+	case "":
+		return "other"
+	}
+	return tokenType
+}
@@ -0,0 +1,57 @@
+package figma
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStyleDictionaryJSON(t *testing.T) {
+	tokens := StyleDictionary{
+		"color.brand.primary": {Value: "#ff0000", Type: "color", Comment: "Main brand color"},
+		"size.space.small":    {Value: 4.0, Type: "dimension"},
+	}
+
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	want := `{"color":{"brand":{"primary":{"value":"#ff0000","type":"color","comment":"Main brand color"}}},"size":{"space":{"small":{"value":4,"type":"dimension"}}}}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s; want %v", data, want)
+	}
+}
+
+func TestStyleDictionaryAttributes(t *testing.T) {
+	ans := StyleDictionaryAttributes("color.background.button.primary.hover.extra")
+	want := map[string]any{"category": "color", "type": "background", "item": "button", "subitem": "primary", "state": "hover"}
+
+	if len(ans) != len(want) {
+		t.Errorf("StyleDictionaryAttributes = %v; want %v", ans, want)
+	}
+
+	for key, value := range want {
+		if ans[key] != value {
+			t.Errorf("%+v = %v; want %v", key, ans[key], value)
+		}
+	}
+}
+
+func TestStyleDictionaryCategory(t *testing.T) {
+	tests := map[string]string{
+		"color":      "color",
+		"dimension":  "size",
+		"number":     "number",
+		"fontWeight": "font",
+		"string":     "content",
+		"typography": "font",
+		"shadow":     "shadow",
+		"":           "other",
+	}
+
+	for tokenType, want := range tests {
+		if ans := StyleDictionaryCategory(tokenType); ans != want {
+			t.Errorf("%+v = %v; want %v", tokenType, ans, want)
+		}
+	}
+}
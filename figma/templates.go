@@ -1,15 +1,22 @@
 package figma
 
 const CssVariablesTemplate = `
-{{- range $selector, $rules := . }}
-{{if ne $selector ":root" -}}
-.
-{{- end -}}
-{{$selector}} {
-{{- range $rules }}
+{{- range . }}
+{{if eq (slice .Selector 0 1) "@" -}}
+{{ .Selector }} {
+:root {
+{{- range .Rules }}
+ 	{{ . }}
+{{- end }}
+}
+}
+{{- else -}}
+{{ .Selector }} {
+{{- range .Rules }}
  	{{ . }}
 {{- end }}
 }
+{{- end }}
 {{ end }}`
 
 const CssComponentsTemplate = `
@@ -40,3 +47,156 @@ const HtmlComponentsTemplate = `
 {{ end -}}
 {{template "component" .}}
 `
+
+// Templates for TokenData, the default theme holds the tokens declared in :root.
+
+const ScssTokensTemplate = `
+{{- range .Themes }}{{ if eq .Name "default" }}{{ range .Tokens -}}
+${{ .Name }}: {{ .Value }};
+{{ end }}{{ end }}{{ end }}
+{{- range .Themes }}
+$tokens-{{ .Name }}: (
+{{- range .Tokens }}
+	"{{ .Name }}": ({{ .Value }}),
+{{- end }}
+);
+{{ end }}
+$themes: (
+{{- range .Themes }}
+	"{{ .Name }}": $tokens-{{ .Name }},
+{{- end }}
+);
+{{- range .Classes }}
+
+@mixin {{ .Name }} {
+{{- range .Properties }}
+	{{ .Name }}: {{ .Value }};
+{{- end }}
+}
+{{- end }}
+{{- range .Grids }}
+
+@mixin {{ .Name }} {
+{{- range .Properties }}
+	{{ .Name }}: {{ .Value }};
+{{- end }}
+}
+{{- end }}
+`
+
+const LessTokensTemplate = `
+{{- range .Themes }}{{ if eq .Name "default" }}{{ range .Tokens -}}
+@{{ .Name }}: {{ .Value }};
+{{ end }}{{ end }}{{ end }}
+{{- range .Themes }}
+@tokens-{{ .Name }}: {
+{{- range .Tokens }}
+	{{ .Name }}: {{ .Value }};
+{{- end }}
+}
+{{ end }}
+{{- range .Classes }}
+.{{ .Name }}() {
+{{- range .Properties }}
+	{{ .Name }}: {{ .Value }};
+{{- end }}
+}
+{{ end -}}
+{{- range .Grids }}
+.{{ .Name }}() {
+{{- range .Properties }}
+	{{ .Name }}: {{ .Value }};
+{{- end }}
+}
+{{ end -}}
+`
+
+const TypeScriptTokensTemplate = `export const tokens = {
+{{- range .Themes }}
+	"{{ .Name }}": {
+{{- range .Tokens }}
+		"{{ .Key }}": {{ .Quoted }},
+{{- end }}
+	},
+{{- end }}
+} as const;
+
+export type Theme = keyof typeof tokens;
+
+export const textStyles = {
+{{- range .Classes }}
+	"{{ .Key }}": {
+{{- range .Properties }}
+		"{{ .Key }}": {{ .Quoted }},
+{{- end }}
+	},
+{{- end }}
+} as const;
+{{- if .Grids }}
+
+export const gridStyles = {
+{{- range .Grids }}
+	"{{ .Key }}": {
+{{- range .Properties }}
+		"{{ .Key }}": {{ .Quoted }},
+{{- end }}
+	},
+{{- end }}
+} as const;
+{{- end }}
+`
+
+const TailwindConfigTemplate = `export default {
+	theme: {
+		extend: {
+{{- range .Categories }}
+			{{ .Name }}: {
+{{- range .Values }}
+				"{{ .Name }}": {{ .Quoted }},
+{{- end }}
+			},
+{{- end }}
+		},
+	},
+};
+`
+
+// Theme variables that reference other variables are inline, so utilities use the referenced variable.
+// Token variables that already have the theme variable name can not reference themselves and keep their value.
+const TailwindThemeTemplate = `@theme inline {
+{{- range .Inline }}
+	--{{ .Name }}: {{ .Value }};
+{{- end }}
+}
+{{- if .Static }}
+
+@theme {
+{{- range .Static }}
+	--{{ .Name }}: {{ .Value }};
+{{- end }}
+}
+{{- end }}
+`
+
+const AndroidResourcesTemplate = `<?xml version="1.0" encoding="utf-8"?>
+<resources>
+{{- range . }}
+{{- if eq .Type "float" }}
+	<item name="{{ .Name }}" format="float" type="dimen">{{ .Value }}</item>
+{{- else }}
+	<{{ .Type }} name="{{ .Name }}">{{ .Value }}</{{ .Type }}>
+{{- end }}
+{{- end }}
+</resources>
+`
+
+const SwiftTokensTemplate = `// Generated from Figma variables.
+import SwiftUI
+{{ range . }}
+public enum {{ .Name }} {
+{{- range .Constants }}
+	public static let {{ .Name }}{{ if .Type }}: {{ .Type }}{{ end }} = {{ .Value }}
+{{- end }}
+}
+{{ end -}}
+`
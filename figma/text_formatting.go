@@ -88,6 +88,22 @@ func TokenValues(name string, prefix string) (string, string) {
 	return variable, theme
 }
 
+// Variables have a value per mode, their tokens are keyed by variable and mode.
+func TokenKey(id string, modeId string) string {
+	return id + "/" + modeId
+}
+
+// CssSelector returns the Selector, style tokens only have a Theme which is a class outside of :root.
+func (t Token) CssSelector() string {
+	if t.Selector != "" {
+		return t.Selector
+	}
+	if t.Theme == "" || t.Theme == ":root" {
+		return ":root"
+	}
+	return "." + t.Theme
+}
+
 func CreateTmpl(name, t string) *template.Template {
 	return template.Must(template.New(name).Parse(t))
 }
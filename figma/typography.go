@@ -0,0 +1,148 @@
+package figma
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// TextStyleProperty is a CSS property of a text style, Key names its custom property,
+// e.g. size for --text-heading-size, and Type is its design token type.
+type TextStyleProperty struct {
+	Name  string
+	Key   string
+	Type  string
+	Value string
+}
+
+// Properties returns the CSS properties of the text style in a fixed order.
+func (s *TypeStyle) Properties() []TextStyleProperty {
+	var properties []TextStyleProperty
+	add := func(name string, key string, tokenType string, value string) {
+		properties = append(properties, TextStyleProperty{Name: name, Key: key, Type: tokenType, Value: value})
+	}
+
+	if s.FontFamily != "" {
+		add("font-family", "family", "fontFamily", s.FontFamily)
+	}
+
+	if s.FontSize != 0.0 {
+		add("font-size", "size", "dimension", pixels(s.FontSize))
+	}
+
+	if s.FontWeight != 0.0 {
+		add("font-weight", "weight", "fontWeight", fmt.Sprint(s.FontWeight))
+	}
+
+	if s.Italic {
+		add("font-style", "style", "string", "italic")
+	}
+
+	if lineHeight := s.LineHeight(); lineHeight != "" {
+		add("line-height", "line-height", lineHeightType(lineHeight), lineHeight)
+	}
+
+	if s.LetterSpacing != 0.0 {
+		add("letter-spacing", "letter-spacing", "dimension", pixels(s.LetterSpacing))
+	}
+
+	switch s.TextCase {
+	case TextCaseUpper:
+		add("text-transform", "transform", "string", "uppercase")
+	case TextCaseLower:
+		add("text-transform", "transform", "string", "lowercase")
+	case TextCaseTitle:
+		add("text-transform", "transform", "string", "capitalize")
+	case TextCaseSmallCaps:
+		add("font-variant-caps", "caps", "string", "small-caps")
+	case TextCaseSmallCapsForced:
+		add("font-variant-caps", "caps", "string", "all-small-caps")
+	}
+
+	switch s.TextDecoration {
+	case TextDecorationStrikethrough:
+		add("text-decoration-line", "decoration", "string", "line-through")
+	case TextDecorationUnderline:
+		add("text-decoration-line", "decoration", "string", "underline")
+	}
+
+	if s.ParagraphIndent != 0.0 {
+		add("text-indent", "paragraph-indent", "dimension", pixels(s.ParagraphIndent))
+	}
+
+	// css has no paragraph spacing, it is the space after each paragraph
+	if s.ParagraphSpacing != 0.0 {
+		add("margin-block-end", "paragraph-spacing", "dimension", pixels(s.ParagraphSpacing))
+	}
+
+	if features := s.FontFeatureSettings(); features != "" {
+		add("font-feature-settings", "features", "string", features)
+	}
+
+	return properties
+}
+
+// LineHeight is unitless when the style line height is a percentage of the font size,
+// and normal when it is auto.
+func (s *TypeStyle) LineHeight() string {
+	switch s.LineHeightUnit {
+	case LineHeightUnitFontSize:
+		if s.LineHeightPercentFontSize != 0.0 {
+			return fmt.Sprint(RoundToDecimals(s.LineHeightPercentFontSize/100, 4))
+		}
+	case LineHeightUnitIntrinsic:
+		return "normal"
+	}
+
+	if s.LineHeightPx != 0.0 {
+		return pixels(s.LineHeightPx)
+	}
+	return ""
+}
+
+// Unitless line heights are numbers, normal is a keyword.
+func lineHeightType(lineHeight string) string {
+	switch {
+	case strings.HasSuffix(lineHeight, "px"):
+		return "dimension"
+	case lineHeight == "normal":
+		return "string"
+	}
+	return "number"
+}
+
+// FontFeatureSettings returns the OpenType flags of the style, e.g. "liga" 0, "tnum" 1.
+func (s *TypeStyle) FontFeatureSettings() string {
+	var features []string
+	for _, flag := range slices.Sorted(maps.Keys(s.OpentypeFlags)) {
+		features = append(features, fmt.Sprintf("%q %v", strings.ToLower(flag), s.OpentypeFlags[flag]))
+	}
+	return strings.Join(features, ", ")
+}
+
+// Font returns the font shorthand of the style, empty without a family or size.
+func (s *TypeStyle) Font() string {
+	if s.FontFamily == "" || s.FontSize == 0.0 {
+		return ""
+	}
+
+	var values []string
+	if s.Italic {
+		values = append(values, "italic")
+	}
+	if s.FontWeight != 0.0 {
+		values = append(values, fmt.Sprint(s.FontWeight))
+	}
+
+	size := pixels(s.FontSize)
+	if lineHeight := s.LineHeight(); lineHeight != "" {
+		size += "/" + lineHeight
+	}
+
+	return strings.Join(append(values, size, s.FontFamily), " ")
+}
+
+func pixels(value float64) string {
+	return fmt.Sprintf("%vpx", RoundToDecimals(value, 4))
+}
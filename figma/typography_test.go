@@ -0,0 +1,90 @@
+package figma
+
+import "testing"
+
+func TestTypeStyleProperties(t *testing.T) {
+	style := TypeStyle{
+		FontFamily:       "Inter",
+		FontSize:         14.5,
+		FontWeight:       450,
+		LineHeightPx:     19.363636016845703,
+		LetterSpacing:    -0.25,
+		TextCase:         TextCaseSmallCaps,
+		TextDecoration:   TextDecorationStrikethrough,
+		ParagraphSpacing: 12,
+		OpentypeFlags:    map[string]float64{"TNUM": 1, "LIGA": 0},
+	}
+
+	want := map[string]string{
+		"font-family":           "Inter",
+		"font-size":             "14.5px",
+		"font-weight":           "450",
+		"line-height":           "19.3636px",
+		"letter-spacing":        "-0.25px",
+		"font-variant-caps":     "small-caps",
+		"text-decoration-line":  "line-through",
+		"margin-block-end":      "12px",
+		"font-feature-settings": `"liga" 0, "tnum" 1`,
+	}
+
+	types := map[string]string{
+		"font-family":           "fontFamily",
+		"font-size":             "dimension",
+		"font-weight":           "fontWeight",
+		"line-height":           "dimension",
+		"letter-spacing":        "dimension",
+		"font-variant-caps":     "string",
+		"text-decoration-line":  "string",
+		"margin-block-end":      "dimension",
+		"font-feature-settings": "string",
+	}
+
+	ans := style.Properties()
+	if len(ans) != len(want) {
+		t.Errorf("%+v = %v; want %v properties", "Properties", ans, len(want))
+	}
+
+	for _, property := range ans {
+		if property.Value != want[property.Name] {
+			t.Errorf("%+v = %v; want %v", property.Name, property.Value, want[property.Name])
+		}
+		if property.Type != types[property.Name] {
+			t.Errorf("%+v type = %v; want %v", property.Name, property.Type, types[property.Name])
+		}
+	}
+}
+
+func TestTypeStyleLineHeight(t *testing.T) {
+	tests := []struct {
+		style TypeStyle
+		want  string
+	}{
+		{TypeStyle{LineHeightPx: 24}, "24px"},
+		{TypeStyle{LineHeightPx: 24, LineHeightUnit: LineHeightUnitPixels}, "24px"},
+		{TypeStyle{LineHeightPx: 24, LineHeightPercentFontSize: 150, LineHeightUnit: LineHeightUnitFontSize}, "1.5"},
+		{TypeStyle{LineHeightPx: 24, LineHeightUnit: LineHeightUnitIntrinsic}, "normal"},
+		{TypeStyle{}, ""},
+	}
+
+	for _, test := range tests {
+		ans := test.style.LineHeight()
+		if ans != test.want {
+			t.Errorf("%+v = %v; want %v", test.style.LineHeightUnit, ans, test.want)
+		}
+	}
+}
+
+func TestTypeStyleFont(t *testing.T) {
+	style := TypeStyle{FontFamily: "Open Sans", FontSize: 16, FontWeight: 400, LineHeightPx: 24}
+
+	ans := style.Font()
+	want := "400 16px/24px Open Sans"
+	if ans != want {
+		t.Errorf("%+v = %v; want %v", "Font", ans, want)
+	}
+
+	style.FontSize = 0
+	if ans := style.Font(); ans != "" {
+		t.Errorf("%+v = %v; want empty without a size", "Font", ans)
+	}
+}
@@ -0,0 +1,164 @@
+package figma
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type VariableValueKind string
+
+const (
+	VariableValueKindColor   VariableValueKind = "COLOR"
+	VariableValueKindFloat   VariableValueKind = "FLOAT"
+	VariableValueKindString  VariableValueKind = "STRING"
+	VariableValueKindBoolean VariableValueKind = "BOOLEAN"
+	VariableValueKindAlias   VariableValueKind = "VARIABLE_ALIAS"
+)
+
+// VariableValue is the value of a variable for one mode, only the field matching Kind is set.
+type VariableValue struct {
+	Kind    VariableValueKind
+	Color   Color
+	Float   float64
+	String  string
+	Boolean bool
+	Alias   VariableAlias
+}
+
+func (v *VariableValue) IsAlias() bool {
+	return v.Kind == VariableValueKindAlias
+}
+
+// The API sends colors and aliases as objects, and the other types as plain JSON values.
+func (v *VariableValue) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return fmt.Errorf("empty variable value")
+	}
+
+	switch data[0] {
+	case '{':
+		var object struct {
+			Type string `json:"type"`
+			ID   string `json:"id"`
+			Color
+		}
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+
+		if object.Type == string(VariableValueKindAlias) {
+			*v = VariableValue{Kind: VariableValueKindAlias, Alias: VariableAlias{Type: object.Type, ID: object.ID}}
+		} else {
+			*v = VariableValue{Kind: VariableValueKindColor, Color: object.Color}
+		}
+	case '"':
+		*v = VariableValue{Kind: VariableValueKindString}
+		return json.Unmarshal(data, &v.String)
+	case 't', 'f':
+		*v = VariableValue{Kind: VariableValueKindBoolean}
+		return json.Unmarshal(data, &v.Boolean)
+	default:
+		*v = VariableValue{Kind: VariableValueKindFloat}
+		return json.Unmarshal(data, &v.Float)
+	}
+
+	return nil
+}
+
+func (v VariableValue) MarshalJSON() ([]byte, error) {
+	switch v.Kind {
+	case VariableValueKindColor:
+		return json.Marshal(v.Color)
+	case VariableValueKindString:
+		return json.Marshal(v.String)
+	case VariableValueKindBoolean:
+		return json.Marshal(v.Boolean)
+	case VariableValueKindAlias:
+		return json.Marshal(v.Alias)
+	default:
+		return json.Marshal(v.Float)
+	}
+}
+
+// Variable returns the variable an alias points to, remote variables are only found
+// when the file uses them.
+func (m *Meta) Variable(alias VariableAlias) (Variable, bool) {
+	variable, ok := m.Variables[alias.ID]
+	return variable, ok
+}
+
+// Value returns the variable value for a mode, variables from other collections
+// do not have the mode and use the default mode of their own collection.
+func (m *Meta) Value(variable Variable, modeId string) (VariableValue, bool) {
+	if value, ok := variable.ValuesByMode[modeId]; ok {
+		return value, true
+	}
+
+	collection, ok := m.VariableCollections[variable.VariableCollectionId]
+	if !ok {
+		return VariableValue{}, false
+	}
+
+	value, ok := variable.ValuesByMode[collection.DefaultModeId]
+	return value, ok
+}
+
+var (
+	ErrAliasNotFound = errors.New("figma: alias target not found")
+	ErrAliasCycle    = errors.New("figma: alias cycle")
+	ErrValueNotFound = errors.New("figma: variable has no value for the mode")
+)
+
+// AliasError is returned when a variable value can not be resolved,
+// use errors.Is with ErrAliasNotFound, ErrAliasCycle and ErrValueNotFound for the reason.
+type AliasError struct {
+	Variable string   // Name of the variable being resolved
+	ModeId   string   // Mode being resolved
+	Chain    []string // Variable IDs followed, the last one could not be resolved
+	Err      error
+}
+
+func (e *AliasError) Error() string {
+	return fmt.Sprintf("%v: variable %v in mode %v: %v", e.Err, e.Variable, e.ModeId, strings.Join(e.Chain, " -> "))
+}
+
+func (e *AliasError) Unwrap() error {
+	return e.Err
+}
+
+// Resolve follows the alias chain of a variable value for a mode, across collections,
+// and returns the first value that is not an alias.
+func (m *Meta) Resolve(variable Variable, modeId string) (VariableValue, error) {
+	aliasError := &AliasError{Variable: variable.Name, ModeId: modeId}
+	seen := make(map[string]bool)
+
+	for {
+		aliasError.Chain = append(aliasError.Chain, variable.ID)
+		if seen[variable.ID] {
+			aliasError.Err = ErrAliasCycle
+			return VariableValue{}, aliasError
+		}
+		seen[variable.ID] = true
+
+		value, ok := m.Value(variable, modeId)
+		if !ok {
+			aliasError.Err = ErrValueNotFound
+			return VariableValue{}, aliasError
+		}
+
+		if !value.IsAlias() {
+			return value, nil
+		}
+
+		variable, ok = m.Variable(value.Alias)
+		if !ok {
+			aliasError.Chain = append(aliasError.Chain, value.Alias.ID)
+			aliasError.Err = ErrAliasNotFound
+			return VariableValue{}, aliasError
+		}
+	}
+}
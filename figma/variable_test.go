@@ -0,0 +1,105 @@
+package figma
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVariableValueUnmarshal(t *testing.T) {
+	var values map[string]VariableValue
+
+	data := []byte(`{
+		"color": {"r": 1, "g": 0.5, "b": 0, "a": 1},
+		"alias": {"type": "VARIABLE_ALIAS", "id": "VariableID:1:2"},
+		"float": 16,
+		"string": "Inter",
+		"boolean": true
+	}`)
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	want := map[string]VariableValue{
+		"color":   {Kind: VariableValueKindColor, Color: Color{Red: 1, Green: 0.5, Blue: 0, Alpha: 1}},
+		"alias":   {Kind: VariableValueKindAlias, Alias: VariableAlias{Type: "VARIABLE_ALIAS", ID: "VariableID:1:2"}},
+		"float":   {Kind: VariableValueKindFloat, Float: 16},
+		"string":  {Kind: VariableValueKindString, String: "Inter"},
+		"boolean": {Kind: VariableValueKindBoolean, Boolean: true},
+	}
+
+	for key, ans := range values {
+		if ans != want[key] {
+			t.Errorf("%+v = %+v; want %+v", key, ans, want[key])
+		}
+	}
+
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	var decoded map[string]VariableValue
+	if err := json.Unmarshal(encoded, &decoded); err != nil || len(decoded) != len(want) {
+		t.Fatalf("Unmarshal %s = %v, %v", encoded, decoded, err)
+	}
+
+	for key, ans := range decoded {
+		if ans != want[key] {
+			t.Errorf("%+v = %+v; want %+v after marshalling", key, ans, want[key])
+		}
+	}
+}
+
+func TestMetaResolve(t *testing.T) {
+	primitive := VariableValue{Kind: VariableValueKindColor, Color: Color{Red: 1, Alpha: 1}}
+
+	meta := Meta{
+		VariableCollections: map[string]VariableCollection{
+			"primitives": {ID: "primitives", DefaultModeId: "1:0"},
+			"semantic":   {ID: "semantic", DefaultModeId: "2:0"},
+		},
+		Variables: map[string]Variable{
+			"red": {ID: "red", VariableCollectionId: "primitives", ValuesByMode: map[string]VariableValue{"1:0": primitive}},
+			"danger": {ID: "danger", VariableCollectionId: "semantic", ValuesByMode: map[string]VariableValue{
+				"2:0": {Kind: VariableValueKindAlias, Alias: VariableAlias{ID: "red"}},
+				"2:1": {Kind: VariableValueKindAlias, Alias: VariableAlias{ID: "missing"}},
+			}},
+			"error": {ID: "error", VariableCollectionId: "semantic", ValuesByMode: map[string]VariableValue{
+				"2:0": {Kind: VariableValueKindAlias, Alias: VariableAlias{ID: "danger"}},
+			}},
+			"loop": {ID: "loop", VariableCollectionId: "semantic", ValuesByMode: map[string]VariableValue{
+				"2:0": {Kind: VariableValueKindAlias, Alias: VariableAlias{ID: "loop"}},
+			}},
+		},
+	}
+
+	tests := []struct {
+		variable string
+		mode     string
+		want     VariableValue
+		err      error
+	}{
+		{"red", "1:0", primitive, nil},
+		{"danger", "2:0", primitive, nil},
+		{"error", "2:0", primitive, nil},
+		{"danger", "2:1", VariableValue{}, ErrAliasNotFound},
+		{"loop", "2:0", VariableValue{}, ErrAliasCycle},
+		{"red", "9:9", primitive, nil}, // missing modes use the default mode
+	}
+
+	for _, test := range tests {
+		ans, err := meta.Resolve(meta.Variables[test.variable], test.mode)
+		if ans != test.want || !errors.Is(err, test.err) {
+			t.Errorf("%+v = %+v, %v; want %+v, %v", test.variable, ans, err, test.want, test.err)
+		}
+	}
+
+	_, err := meta.Resolve(meta.Variables["error"], "2:1")
+	var aliasError *AliasError
+	if !errors.As(err, &aliasError) || strings.Join(aliasError.Chain, ",") != "error,danger,missing" {
+		t.Errorf("Resolve error = %v; want chain error,danger,missing", err)
+	}
+}
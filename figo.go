@@ -2,13 +2,17 @@ package figo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"log/slog"
+	"maps"
 	"net/http"
+	"net/url"
 	"os"
-	"reflect"
-	"sort"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,13 +21,38 @@ import (
 )
 
 type Figma struct {
-	FILE_KEY string
-	API_KEY  string
-	Prefix   string // Prefix for components tag
+	FILE_KEY   string
+	API_KEY    string
+	Version    string              // File version to export, see GetVersions, the latest version when empty. Variables are always the latest
+	Prefix     string              // Prefix for components tag
+	Images     figma.ImageResolver // Resolves image fills urls, image fills are skipped when nil
+	HttpClient *http.Client        // Client for API requests, defaults to a client with a 10 seconds timeout
+	Transport  http.RoundTripper   // Transport for the default client, ignored when HttpClient is set
+	BaseUrl    string              // Figma API host, defaults to DefaultBaseUrl
+	UserAgent  string              // User-Agent header sent with every request
+	Retry      *RetryPolicy        // Retries for rate limited and failed requests, defaults to DefaultRetryPolicy
+	Logger     *slog.Logger        // Traces requests, parsing and unsupported features, nothing is logged when nil
+	Cache      *Cache              // Saves file, nodes and variables responses on disk, disabled when nil
+
+	ResolveAliases bool          // Variable aliases are output as their resolved values instead of var() references
+	ThemeSelector  ThemeSelector // Selector of each variable mode, defaults to DataThemeSelector
+	ColorScheme    *ColorScheme  // Output of light and dark modes, they are treated like other modes when nil
+
+	SkipHidden        bool // Variables and collections hidden from publishing are not output
+	LineHeightPercent bool // LINE_HEIGHT variables are percentages of the font size, output as unitless line heights
+	FontShorthand     bool // Text styles also have a font shorthand custom property, e.g. --text-heading-font
+	LayoutGrids       bool // Frames with layout grids are CSS grids, their children must be laid out in the grid tracks
+}
+
+func (f *Figma) logger() *slog.Logger {
+	if f.Logger != nil {
+		return f.Logger
+	}
+	return slog.New(slog.DiscardHandler)
 }
 
 func (figma *Figma) getUri() (string, error) {
-	component_url := `https://api.figma.com/v1/files/{{.FILE_KEY}}`
+	component_url := figma.baseUrl() + `/v1/files/{{.FILE_KEY}}`
 
 	t := fg.CreateTmpl("figma_uri", component_url)
 
@@ -34,11 +63,17 @@ func (figma *Figma) getUri() (string, error) {
 		return "", err
 	}
 
+	if figma.Version != "" {
+		query := url.Values{}
+		query.Set("version", figma.Version)
+		return result.String() + "?" + query.Encode(), nil
+	}
+
 	return result.String(), nil
 }
 
 func (figma *Figma) getVariablesUri() (string, error) {
-	component_url := `https://api.figma.com/v1/files/{{.FILE_KEY}}/variables/local`
+	component_url := figma.baseUrl() + `/v1/files/{{.FILE_KEY}}/variables/local`
 	t := fg.CreateTmpl("figma_uri", component_url)
 
 	var result bytes.Buffer
@@ -52,129 +87,93 @@ func (figma *Figma) getVariablesUri() (string, error) {
 }
 
 func (f *Figma) GetData() (figma.File, error) {
-	var file figma.File
+	return f.GetDataContext(context.Background())
+}
 
-	// Create a new HTTP client with a timeout
-	client := &http.Client{
-		Timeout: 10 * time.Second, // may need longer timeout as figma files tend to get big
-	}
+func (f *Figma) GetDataContext(ctx context.Context) (figma.File, error) {
+	var file figma.File
 
 	uri, uriError := f.getUri()
 	if uriError != nil {
 		return file, uriError
 	}
 
-	req, requestError := http.NewRequest("GET", uri, nil)
-	if requestError != nil {
-		return file, requestError
-	}
-
-	req.Header.Set("X-Figma-Token", f.API_KEY)
-
-	httpResp, httpError := client.Do(req)
+	body, httpError := f.getCached(ctx, uri)
 	if httpError != nil {
 		return file, httpError
 	}
 
-	defer httpResp.Body.Close()
-
-	if httpResp.StatusCode != http.StatusOK {
-		return file, fmt.Errorf("HTTP status code is %+v", httpResp.StatusCode)
-	}
-
-	body, readBodyError := io.ReadAll(httpResp.Body)
-	if readBodyError != nil {
-		return file, readBodyError
-	}
-
-	if unmarshallingError := json.Unmarshal(body, &file); unmarshallingError != nil {
-		return file, unmarshallingError
-	}
-
-	figma.SetDefaults(&file)
-
-	return file, nil
+	return f.parseFile(body)
 }
 
 func (f *Figma) GetVariablesData() (figma.Variables, error) {
-	var variables figma.Variables
+	return f.GetVariablesDataContext(context.Background())
+}
 
-	// Create a new HTTP client with a timeout
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
+func (f *Figma) GetVariablesDataContext(ctx context.Context) (figma.Variables, error) {
+	var variables figma.Variables
 
 	uri, uriError := f.getVariablesUri()
 	if uriError != nil {
 		return variables, uriError
 	}
 
-	req, requestError := http.NewRequest("GET", uri, nil)
-	if requestError != nil {
-		return variables, requestError
+	if f.Version != "" {
+		f.logger().Warn("figma variables can not be pinned to a version, the latest variables are used", "version", f.Version)
 	}
 
-	req.Header.Set("X-Figma-Token", f.API_KEY)
-
-	httpResp, httpError := client.Do(req)
+	body, httpError := f.getCached(ctx, uri)
 	if httpError != nil {
 		return variables, httpError
 	}
 
-	defer httpResp.Body.Close()
-
-	if httpResp.StatusCode != http.StatusOK {
-		return variables, fmt.Errorf("HTTP status code is %+v", httpResp.StatusCode)
-	}
+	return f.parseVariables(body)
+}
 
-	body, readBodyError := io.ReadAll(httpResp.Body)
-	if readBodyError != nil {
-		return variables, readBodyError
-	}
+func (f *Figma) GetDataFromFile(path string) (figma.File, error) {
+	var file figma.File
 
-	if unmarshallingError := json.Unmarshal(body, &variables); unmarshallingError != nil {
-		return variables, unmarshallingError
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return file, err
 	}
 
-	figma.SetDefaults(&variables)
-
-	return variables, nil
+	return f.parseFile(data)
 }
 
-func (f *Figma) GetDataFromFile(path string) (figma.File, error) {
-	var file figma.File
+func (f *Figma) GetVariablesFromFile(path string) (figma.Variables, error) {
+	var variables figma.Variables
 
 	data, err := os.ReadFile(path)
 	if err != nil {
-		fmt.Println("Error Reading Figma file:", err)
-		return file, err
+		return variables, err
 	}
 
+	return f.parseVariables(data)
+}
+
+func (f *Figma) parseFile(data []byte) (figma.File, error) {
+	var file figma.File
+	start := time.Now()
+
 	if unmarshallingError := json.Unmarshal(data, &file); unmarshallingError != nil {
-		fmt.Println("Error Unmarshal Figma file:", unmarshallingError)
 		return file, unmarshallingError
 	}
 
-	figma.SetDefaults(&file)
+	f.logger().Debug("figma file parsed", "name", file.Name, "bytes", len(data), "duration", time.Since(start))
 
 	return file, nil
 }
 
-func (f *Figma) GetVariablesFromFile(path string) (figma.Variables, error) {
+func (f *Figma) parseVariables(data []byte) (figma.Variables, error) {
 	var variables figma.Variables
-
-	data, err := os.ReadFile(path)
-	if err != nil {
-		fmt.Println("Error Reading Figma Variables:", err)
-		return variables, err
-	}
+	start := time.Now()
 
 	if unmarshallingError := json.Unmarshal(data, &variables); unmarshallingError != nil {
-		fmt.Println("Error Unmarshal Figma Variables:", unmarshallingError)
 		return variables, unmarshallingError
 	}
 
-	figma.SetDefaults(&variables)
+	f.logger().Debug("figma variables parsed", "variables", len(variables.Meta.Variables), "bytes", len(data), "duration", time.Since(start))
 
 	return variables, nil
 }
@@ -214,26 +213,34 @@ func (f *Figma) mapTokens(node figma.Node, styles *map[string]figma.Style, token
 			if !hasToken && hasStyle {
 				var value string
 				var className string
+				var tokenType string
 				variable, theme := figma.TokenValues(s.Name, f.Prefix)
 				switch key {
 				case "fills":
-					value = node.Background()
+					value = node.BackgroundWith(f.Images)
+					tokenType = "color"
 				case "strokes":
 					value = node.BorderColor()
+					tokenType = "color"
 				case "effect":
 					value = node.BoxShadow()
-				case "grid": // TODO: get styles for grid
-					value = ""
-					className = ""
+					tokenType = "shadow"
+				case "grid":
+					value = cssRules(node.Grid())
+					className = fmt.Sprintf("grid__style--%v", figma.ToKebabCase(s.Name))
+					theme = ""
+					tokenType = "grid"
 				}
 
 				if value != "" {
 					token := figma.Token{
-						Name:      s.Name,
-						Variable:  variable,
-						Value:     value,
-						Theme:     theme,
-						ClassName: className,
+						Name:        s.Name,
+						Variable:    variable,
+						Value:       value,
+						Theme:       theme,
+						ClassName:   className,
+						Type:        tokenType,
+						Description: s.Description,
 					}
 
 					(*tokens)[id] = token
@@ -250,31 +257,42 @@ func (f *Figma) mapTokens(node figma.Node, styles *map[string]figma.Style, token
 					if !hasToken && hasStyle {
 						var value string
 						var className string
+						var tokenType string
 						variable, theme := figma.TokenValues(s.Name, f.Prefix)
 						switch key {
 						case "text":
 							value = child.Font()
 							className = fmt.Sprintf("text__style--%v", figma.ToKebabCase(s.Name))
 							theme = ""
+							tokenType = "typography"
 						case "fill":
 							value = child.Background()
+							tokenType = "color"
 						case "stroke":
 							value = child.BorderColor()
+							tokenType = "color"
 						case "effect":
 							value = child.BoxShadow()
+							tokenType = "shadow"
 						}
 
 						if value != "" {
 							token := figma.Token{
-								Name:      s.Name,
-								Variable:  variable,
-								Value:     value,
-								Theme:     theme,
-								ClassName: className,
+								Name:        s.Name,
+								Variable:    variable,
+								Value:       value,
+								Theme:       theme,
+								ClassName:   className,
+								Type:        tokenType,
+								Description: s.Description,
 							}
 
 							(*tokens)[id] = token
 							// fmt.Printf("[token] : %+v \n\n", token)
+
+							if key == "text" {
+								f.textStyleTokens(child, s, id, tokens)
+							}
 						}
 					}
 				}
@@ -285,107 +303,207 @@ func (f *Figma) mapTokens(node figma.Node, styles *map[string]figma.Style, token
 }
 
 func (f *Figma) GenerateTokensCSS(tokens map[string]figma.Token) (string, error) {
-	tk := make(map[string][]string)
-
-	// Group tokens by theme and generate CSS rules
-	for _, token := range tokens {
-		if _, exists := tk[token.Theme]; !exists && token.Theme != "" {
-			var rules []string
-			for _, t := range tokens {
-				if t.Theme == token.Theme {
-					rules = append(rules, fmt.Sprintf("%s: %s;", t.Variable, t.Value))
-				}
-			}
-			// Sort rules alphabetically (case-insensitive)
-			sort.Slice(rules, func(i, j int) bool {
-				return strings.ToLower(rules[i]) < strings.ToLower(rules[j])
-			})
-			tk[token.Theme] = removeDuplicates(rules)
-		} else if _, exists := tk[token.ClassName]; !exists && token.ClassName != "" {
-			tk[token.ClassName] = strings.Split(token.Value, "|")
-		}
-	}
+	return f.GenerateTokens(tokens, CssEmitter{})
+}
 
-	var out bytes.Buffer
-	tmp := figma.CreateTmpl("tokens", figma.CssVariablesTemplate)
-	err := tmp.Execute(&out, tk)
+// ParseVariables is ParseVariablesChecked without the errors, unresolved variables are logged and skipped.
+func (f *Figma) ParseVariables(variables figma.Variables) map[string]figma.Token {
+	tokens, err := f.ParseVariablesChecked(variables)
 	if err != nil {
-		return "", err
+		f.logger().Warn("unresolved variables skipped", "error", err)
 	}
 
-	return out.String(), nil
+	return tokens
 }
 
-func (f *Figma) ParseVariables(variables figma.Variables) map[string]figma.Token {
+// ParseVariablesChecked returns the tokens of every variable it can resolve, the variables
+// that can not be resolved are reported in the error, see figma.AliasError.
+func (f *Figma) ParseVariablesChecked(variables figma.Variables) (map[string]figma.Token, error) {
 	collections := variables.Meta.VariableCollections
 	vars := variables.Meta.Variables
 	tokens := make(map[string]figma.Token)
-	themes := getVariablesThemes(collections)
+	selectors := f.variableSelectors(collections)
+	modes := variableModes(collections)
+	var errs []error
 
 	if len(collections) == 0 || len(vars) == 0 {
-		return tokens
+		return tokens, nil
 	}
 
 	for _, v := range vars {
-		if (v.ResolvedType == fg.ResolvedTypeColor || v.ResolvedType == fg.ResolvedTypeFloat) && !v.DeletedButReferenced {
-			collectionName := fg.ToKebabCase(collections[v.VariableCollectionId].Name)
-			varName := fg.ToKebabCase(v.Name)
-
+		if f.exported(variables.Meta, v) {
 			for key, value := range v.ValuesByMode {
-				result := ""
-				theme := themes[key]
-				switch reflect.TypeOf(value).Kind() {
-				case reflect.Float64:
-					result = fmt.Sprintf("%vpx", value)
-				case reflect.Map:
-					jsonData, _ := json.Marshal(value) // encode back to JSON
-
-					var color fg.Color
-					var alias fg.VariableAlias
-
-					json.Unmarshal(jsonData, &alias)
-					json.Unmarshal(jsonData, &color)
-
-					result = color.Rgba()
+				// aliases are resolved even when output as var() so broken chains are reported
+				resolved, err := variables.Meta.Resolve(v, key)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
 
-					if alias.ID != "" && vars[alias.ID].VariableCollectionId != "" {
-						prefix := fg.ToKebabCase(collections[vars[alias.ID].VariableCollectionId].Name)
-						result = fmt.Sprintf("var(--%v-%v)", prefix, fg.ToKebabCase(vars[alias.ID].Name))
+				result := f.variableValue(v, resolved)
+				if value.IsAlias() && !f.ResolveAliases {
+					// hidden and deleted variables are not output, their value is used instead
+					if alias, ok := f.aliasTarget(variables.Meta, value); ok {
+						result = fmt.Sprintf("var(%v)", cssVariableName(collections, alias))
 					}
 				}
 
 				if result != "" {
 					token := figma.Token{
 						Name:     v.Name,
-						Variable: fmt.Sprintf("--%v-%v", collectionName, varName),
+						Variable: cssVariableName(collections, v),
 						Value:    result,
-						Theme:    theme,
+						Theme:    fg.ToKebabCase(modes[key]),
+						Mode:     modes[key],
+						Selector: selectors[key],
+
+						Type:        f.variableType(v),
+						Description: v.Description,
+						Collection:  collections[v.VariableCollectionId].Name,
+						Scopes:      v.Scopes,
 					}
 
-					tokens[v.ID] = token
+					tokens[fg.TokenKey(v.ID, key)] = token
 				}
 			}
 		}
 	}
 
-	return tokens
+	f.applyColorScheme(tokens, variables.Meta)
+
+	return tokens, errors.Join(errs...)
+}
+
+// cssRules joins rules as "property: value;" separated by "|" like Node.Font, sorted by property.
+func cssRules(rules map[string]string) string {
+	var value []string
+	for _, property := range slices.Sorted(maps.Keys(rules)) {
+		value = append(value, fmt.Sprintf("%v: %v;", property, rules[property]))
+	}
+	return strings.Join(value, "|")
 }
 
-func getVariablesThemes(collections map[string]fg.VariableCollection) map[string]string {
-	themes := make(map[string]string)
+// textStyleTokens adds a custom property for each property of a text style, e.g. --text-heading-size,
+// and the font shorthand when FontShorthand is set. They are keyed by style ID and property.
+func (f *Figma) textStyleTokens(node figma.Node, s figma.Style, id string, tokens *map[string]figma.Token) {
+	prefix := "--text-"
+	if f.Prefix != "" {
+		prefix = "--" + f.Prefix + "-text-"
+	}
+	name := prefix + figma.ToKebabCase(s.Name) + "-"
 
-	for _, c := range collections {
-		for _, mode := range c.Modes {
-			name := ":root"
-			if strings.HasSuffix(strings.ToLower(mode.Name), "theme") {
-				name = fg.ToKebabCase(mode.Name)
-			}
+	properties := node.Style.Properties()
+	if font := node.Style.Font(); f.FontShorthand && font != "" {
+		properties = append(properties, figma.TextStyleProperty{Name: "font", Key: "font", Type: "string", Value: font})
+	}
+
+	for _, property := range properties {
+		(*tokens)[figma.TokenKey(id, property.Key)] = figma.Token{
+			Name:        s.Name + "/" + property.Key,
+			Variable:    name + property.Key,
+			Value:       property.Value,
+			Type:        property.Type,
+			Description: s.Description,
+		}
+	}
+}
+
+// cssVariableName is the WEB code syntax of the variable, e.g. var(--brand) or --brand,
+// or the collection and variable name in kebab case.
+func cssVariableName(collections map[string]fg.VariableCollection, v fg.Variable) string {
+	if name := webName(v.CodeSyntax); name != "" {
+		return "--" + name
+	}
+
+	return fmt.Sprintf("--%v-%v", fg.ToKebabCase(collections[v.VariableCollectionId].Name), fg.ToKebabCase(v.Name))
+}
+
+// webName is the WEB code syntax without var() and the leading --.
+func webName(codeSyntax fg.VariableCodeSyntax) string {
+	name := strings.TrimSpace(codeSyntax.Web)
+	if strings.HasPrefix(name, "var(") && strings.HasSuffix(name, ")") {
+		name = strings.TrimSpace(name[4 : len(name)-1])
+	}
+	return strings.TrimLeft(name, "-")
+}
+
+func (f *Figma) hidden(meta fg.Meta, v fg.Variable) bool {
+	return f.SkipHidden && (v.HiddenFromPublishing || meta.VariableCollections[v.VariableCollectionId].HiddenFromPublishing)
+}
+
+// exported reports whether a variable is output, aliases to variables that are not are
+// replaced by their resolved value.
+func (f *Figma) exported(meta fg.Meta, v fg.Variable) bool {
+	return !v.DeletedButReferenced && !f.hidden(meta, v)
+}
+
+// aliasTarget returns the variable an alias points to when it is exported.
+func (f *Figma) aliasTarget(meta fg.Meta, value fg.VariableValue) (fg.Variable, bool) {
+	if !value.IsAlias() {
+		return fg.Variable{}, false
+	}
+	alias, ok := meta.Variable(value.Alias)
+	return alias, ok && f.exported(meta, alias)
+}
 
-			themes[mode.ModeId] = name
+// variableUnit picks the unit of FLOAT variables from their scopes, variables that can be
+// used anywhere are px. Font weights and opacities are unitless, line heights are px unless
+// LineHeightPercent is set.
+func (f *Figma) variableUnit(v fg.Variable) string {
+	if len(v.Scopes) == 0 {
+		return "px"
+	}
+
+	for _, scope := range v.Scopes {
+		switch scope {
+		case fg.VariableScopeFontWeight, fg.VariableScopeOpacity:
+		case fg.VariableScopeLineHeight:
+			if !f.LineHeightPercent {
+				return "px"
+			}
+		default:
+			return "px"
 		}
 	}
 
-	return themes
+	return ""
+}
+
+// variableType is the design token type of a variable, FLOAT variables are dimensions
+// unless their scopes make them unitless.
+func (f *Figma) variableType(v fg.Variable) string {
+	if v.ResolvedType != fg.ResolvedTypeFloat || f.variableUnit(v) != "" {
+		return designTokenType(v.ResolvedType)
+	}
+	if !slices.ContainsFunc(v.Scopes, func(scope fg.VariableScope) bool { return scope != fg.VariableScopeFontWeight }) {
+		return "fontWeight"
+	}
+	return "number"
+}
+
+// variableFloat returns a FLOAT value and its unit, opacities and percent line heights are
+// 0 to 100 in Figma and 0 to 1 in CSS.
+func (f *Figma) variableFloat(v fg.Variable, value float64) (float64, string) {
+	unit := f.variableUnit(v)
+	if unit == "" && !slices.Contains(v.Scopes, fg.VariableScopeFontWeight) {
+		return fg.RoundToDecimals(value/100, 4), unit
+	}
+	return value, unit
+}
+
+func (f *Figma) variableValue(v fg.Variable, value fg.VariableValue) string {
+	switch value.Kind {
+	case fg.VariableValueKindFloat:
+		number, unit := f.variableFloat(v, value.Float)
+		return fmt.Sprintf("%v%v", number, unit)
+	case fg.VariableValueKindColor:
+		return value.Color.Rgba()
+	case fg.VariableValueKindString:
+		return strconv.Quote(value.String)
+	case fg.VariableValueKindBoolean:
+		return strconv.FormatBool(value.Boolean)
+	}
+	return ""
 }
 
 // TODO: move this functions to a common place
@@ -474,6 +592,7 @@ func (f *Figma) generateComponent(id string, node figma.Node, parent figma.Node,
 	}
 	if node.IsInstance() {
 		// fmt.Printf("[INSTANCE] : %+v \n\n", (*components)[node.ID].Name)
+		f.logger().Debug("instance children skipped", "node", node.ID, "name", node.Name)
 		return element
 	}
 	if node.IsComponent() {
@@ -489,7 +608,26 @@ func (f *Figma) generateComponent(id string, node figma.Node, parent figma.Node,
 	// }
 
 	if !node.IsComponentSet() && !node.IsInstance() && !node.IsText() && !node.IsVector() {
-		element.Styles = node.Css(parent)
+		element.Styles = node.CssWith(parent, f.Images)
+
+		if filter, ok := node.ImageFilter(f.Images); filter != "" && !ok {
+			f.logger().Debug("unsupported image fill filters skipped", "node", node.ID, "name", node.Name, "filter", filter)
+		}
+
+		for _, fill := range node.Fills {
+			if fill.Type == figma.PaintTypeImage && fill.IsVisible() && fill.Opacity < 1.0 {
+				f.logger().Debug("unsupported image fill opacity skipped", "node", node.ID, "name", node.Name, "opacity", fill.Opacity)
+			}
+		}
+
+		// auto layout frames are flex boxes, their layout grids are only a guide
+		if f.LayoutGrids && !node.IsAutoLayout() {
+			for key, value := range node.Grid() {
+				if key != "display" || node.IsVisible() {
+					element.Styles[key] = value
+				}
+			}
+		}
 	}
 
 	if node.IsText() {
@@ -498,6 +636,9 @@ func (f *Figma) generateComponent(id string, node figma.Node, parent figma.Node,
 		element.Styles = node.TextCss()
 	}
 	// TODO: vector styles
+	if node.IsVector() {
+		f.logger().Debug("unsupported vector styles skipped", "node", node.ID, "name", node.Name)
+	}
 	// fmt.Printf("[STYLES] : %+v \n\n", el.Styles)
 	//
 	// fmt.Printf("[ELEMENT] : %+v \n\n", element)
@@ -0,0 +1,332 @@
+package figo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vpaulo/figo/figma"
+)
+
+func TestGetDataFromFileLogger(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	path := filepath.Join(t.TempDir(), "file.json")
+	os.WriteFile(path, []byte(`{"name":"Design System"}`), 0o644)
+
+	f := Figma{Logger: logger}
+	file, err := f.GetDataFromFile(path)
+	if err != nil || file.Name != "Design System" {
+		t.Fatalf("GetDataFromFile = %+v, %v", file, err)
+	}
+
+	if !strings.Contains(logs.String(), "figma file parsed") {
+		t.Errorf("GetDataFromFile logs = %v; want parse timing", logs.String())
+	}
+
+	_, err = f.GetDataFromFile(filepath.Join(t.TempDir(), "missing.json"))
+	if !os.IsNotExist(err) {
+		t.Errorf("GetDataFromFile error = %v; want not exist", err)
+	}
+
+	// nil logger must not panic
+	f = Figma{}
+	if _, err := f.GetDataFromFile(path); err != nil {
+		t.Errorf("GetDataFromFile error = %v", err)
+	}
+}
+
+func TestParseVariables(t *testing.T) {
+	var variables figma.Variables
+
+	data := []byte(`{"meta": {
+		"variableCollections": {
+			"C:1": {"id": "C:1", "name": "Theme", "defaultModeId": "1:0", "modes": [{"modeId": "1:0", "name": "Default"}]}
+		},
+		"variables": {
+			"V:1": {"id": "V:1", "name": "Brand", "variableCollectionId": "C:1", "resolvedType": "COLOR", "valuesByMode": {"1:0": {"r": 1, "g": 0, "b": 0, "a": 1}}},
+			"V:2": {"id": "V:2", "name": "Primary", "variableCollectionId": "C:1", "resolvedType": "COLOR", "valuesByMode": {"1:0": {"type": "VARIABLE_ALIAS", "id": "V:1"}}},
+			"V:3": {"id": "V:3", "name": "Font", "variableCollectionId": "C:1", "resolvedType": "STRING", "valuesByMode": {"1:0": "Inter"}},
+			"V:4": {"id": "V:4", "name": "Rounded", "variableCollectionId": "C:1", "resolvedType": "BOOLEAN", "valuesByMode": {"1:0": true}},
+			"V:5": {"id": "V:5", "name": "Space", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "valuesByMode": {"1:0": 8}},
+			"V:6": {"id": "V:6", "name": "Remote", "variableCollectionId": "C:1", "resolvedType": "COLOR", "valuesByMode": {"1:0": {"type": "VARIABLE_ALIAS", "id": "V:99"}}}
+		}
+	}}`)
+
+	if err := json.Unmarshal(data, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	tokens, err := f.ParseVariablesChecked(variables)
+	if !errors.Is(err, figma.ErrAliasNotFound) || !strings.Contains(err.Error(), "V:6 -> V:99") {
+		t.Errorf("ParseVariablesChecked error = %v; want V:6 alias not found", err)
+	}
+
+	want := map[string]string{
+		"V:1": "rgba(255,0,0,1)",
+		"V:2": "var(--theme-brand)",
+		"V:3": `"Inter"`,
+		"V:4": "true",
+		"V:5": "8px",
+	}
+
+	if len(tokens) != len(want) {
+		t.Errorf("ParseVariables = %+v; want %v tokens", tokens, len(want))
+	}
+
+	for id, value := range want {
+		if ans := tokens[figma.TokenKey(id, "1:0")].Value; ans != value {
+			t.Errorf("%+v = %v; want %v", id, ans, value)
+		}
+	}
+
+	f.ResolveAliases = true
+	if ans := f.ParseVariables(variables)[figma.TokenKey("V:2", "1:0")].Value; ans != want["V:1"] {
+		t.Errorf("V:2 = %v; want %v with resolved aliases", ans, want["V:1"])
+	}
+}
+
+func TestParseVariablesScopes(t *testing.T) {
+	var variables figma.Variables
+
+	data := []byte(`{"meta": {
+		"variableCollections": {
+			"C:1": {"id": "C:1", "name": "Size", "defaultModeId": "1:0", "modes": [{"modeId": "1:0", "name": "Default"}]}
+		},
+		"variables": {
+			"V:1": {"id": "V:1", "name": "Gap", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["GAP", "WIDTH_HEIGHT"], "valuesByMode": {"1:0": 8}},
+			"V:2": {"id": "V:2", "name": "Bold", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["FONT_WEIGHT"], "valuesByMode": {"1:0": 700}},
+			"V:3": {"id": "V:3", "name": "Faded", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["OPACITY"], "valuesByMode": {"1:0": 50}},
+			"V:4": {"id": "V:4", "name": "Leading", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["LINE_HEIGHT"], "valuesByMode": {"1:0": 150}},
+			"V:5": {"id": "V:5", "name": "Radius", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["CORNER_RADIUS"], "codeSyntax": {"WEB": "var(--radius-md)"}, "valuesByMode": {"1:0": 4}},
+			"V:6": {"id": "V:6", "name": "Base", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "hiddenFromPublishing": true, "valuesByMode": {"1:0": 2}},
+			"V:7": {"id": "V:7", "name": "Card", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "valuesByMode": {"1:0": {"type": "VARIABLE_ALIAS", "id": "V:5"}}},
+			"V:8": {"id": "V:8", "name": "Border", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "valuesByMode": {"1:0": {"type": "VARIABLE_ALIAS", "id": "V:6"}}}
+		}
+	}}`)
+
+	if err := json.Unmarshal(data, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{SkipHidden: true, LineHeightPercent: true}
+	tokens := f.ParseVariables(variables)
+
+	want := map[string]string{
+		"V:1": "8px",
+		"V:2": "700",
+		"V:3": "0.5",
+		"V:4": "1.5",
+		"V:5": "4px",
+		"V:7": "var(--radius-md)",
+		"V:8": "2px",
+	}
+
+	if len(tokens) != len(want) {
+		t.Errorf("ParseVariables = %+v; want %v tokens", tokens, len(want))
+	}
+
+	for id, value := range want {
+		if ans := tokens[figma.TokenKey(id, "1:0")].Value; ans != value {
+			t.Errorf("%+v = %v; want %v", id, ans, value)
+		}
+	}
+
+	if ans := tokens[figma.TokenKey("V:5", "1:0")].Variable; ans != "--radius-md" {
+		t.Errorf("V:5 variable = %v; want --radius-md", ans)
+	}
+
+	f = Figma{}
+	if ans := f.ParseVariables(variables)[figma.TokenKey("V:4", "1:0")].Value; ans != "150px" {
+		t.Errorf("V:4 = %v; want 150px", ans)
+	}
+}
+
+func TestParseVariablesDeletedAlias(t *testing.T) {
+	var variables figma.Variables
+
+	data := []byte(`{"meta": {
+		"variableCollections": {
+			"C:1": {"id": "C:1", "name": "Color", "defaultModeId": "1:0", "modes": [{"modeId": "1:0", "name": "Light"}, {"modeId": "1:1", "name": "Dark"}]}
+		},
+		"variables": {
+			"V:1": {"id": "V:1", "name": "Blue", "variableCollectionId": "C:1", "resolvedType": "COLOR", "valuesByMode": {"1:0": {"r": 0, "g": 0, "b": 1, "a": 1}, "1:1": {"r": 0, "g": 0, "b": 1, "a": 1}}},
+			"V:2": {"id": "V:2", "name": "Old", "variableCollectionId": "C:1", "resolvedType": "COLOR", "deletedButReferenced": true, "valuesByMode": {"1:0": {"r": 1, "g": 0, "b": 0, "a": 1}, "1:1": {"r": 1, "g": 0, "b": 0, "a": 1}}},
+			"V:3": {"id": "V:3", "name": "Link", "variableCollectionId": "C:1", "resolvedType": "COLOR", "codeSyntax": {"WEB": "--link"}, "valuesByMode": {"1:0": {"type": "VARIABLE_ALIAS", "id": "V:1"}, "1:1": {"type": "VARIABLE_ALIAS", "id": "V:2"}}}
+		}
+	}}`)
+
+	if err := json.Unmarshal(data, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	tokens, err := f.ParseVariablesChecked(variables)
+	if err != nil {
+		t.Fatalf("ParseVariablesChecked error = %v", err)
+	}
+
+	want := map[string]string{
+		figma.TokenKey("V:1", "1:0"): "rgba(0,0,255,1)",
+		figma.TokenKey("V:1", "1:1"): "rgba(0,0,255,1)",
+		figma.TokenKey("V:3", "1:0"): "var(--color-blue)",
+		figma.TokenKey("V:3", "1:1"): "rgba(255,0,0,1)",
+	}
+
+	if len(tokens) != len(want) {
+		t.Errorf("ParseVariables = %+v; want %v tokens", tokens, len(want))
+	}
+
+	for key, value := range want {
+		if ans := tokens[key].Value; ans != value {
+			t.Errorf("%+v = %v; want %v", key, ans, value)
+		}
+	}
+}
+
+func TestParseTokensTextStyle(t *testing.T) {
+	var file figma.File
+
+	data := []byte(`{"document": {"children": [{"type": "CANVAS", "children": [{"type": "FRAME", "children": [{
+		"type": "TEXT",
+		"styles": {"text": "S:1"},
+		"style": {"fontFamily": "Inter", "fontSize": 32, "fontWeight": 700, "italic": true, "letterSpacing": 0.5, "lineHeightPx": 48, "lineHeightPercentFontSize": 150, "lineHeightUnit": "FONT_SIZE_%"}
+	}]}]}]}, "styles": {"S:1": {"name": "Heading"}}}`)
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{FontShorthand: true}
+	tokens := f.ParseTokens(file)
+
+	want := map[string]string{
+		"S:1":                "font-family: Inter;|font-size: 32px;|font-weight: 700;|font-style: italic;|line-height: 1.5;|letter-spacing: 0.5px;",
+		"S:1/size":           "32px",
+		"S:1/letter-spacing": "0.5px",
+		"S:1/font":           "italic 700 32px/1.5 Inter",
+	}
+
+	for key, value := range want {
+		if ans := tokens[key].Value; ans != value {
+			t.Errorf("%+v = %v; want %v", key, ans, value)
+		}
+	}
+
+	types := map[string]string{
+		"S:1/family":      "fontFamily",
+		"S:1/size":        "dimension",
+		"S:1/weight":      "fontWeight",
+		"S:1/style":       "string",
+		"S:1/line-height": "number",
+		"S:1/font":        "string",
+	}
+
+	for key, tokenType := range types {
+		if ans := tokens[key].Type; ans != tokenType {
+			t.Errorf("%+v type = %v; want %v", key, ans, tokenType)
+		}
+	}
+
+	if ans := tokens["S:1/size"].Variable; ans != "--text-heading-size" {
+		t.Errorf("S:1/size variable = %v; want --text-heading-size", ans)
+	}
+
+	if ans := tokens["S:1"].ClassName; ans != "text__style--heading" {
+		t.Errorf("S:1 class = %v; want text__style--heading", ans)
+	}
+}
+
+func TestParseTokensGridStyle(t *testing.T) {
+	var file figma.File
+
+	data := []byte(`{"document": {"children": [{"type": "CANVAS", "children": [{
+		"type": "FRAME",
+		"styles": {"grid": "S:1"},
+		"layoutGrids": [{"pattern": "COLUMNS", "alignment": "STRETCH", "count": 12, "gutterSize": 24, "offset": 32, "sectionSize": 10}]
+	}]}]}, "styles": {"S:1": {"name": "Desktop/Columns"}}}`)
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	token := f.ParseTokens(file)["S:1"]
+
+	want := figma.Token{
+		Name:      "Desktop/Columns",
+		Variable:  "--desktop-columns",
+		Value:     "column-gap: 24px;|display: grid;|grid-template-columns: repeat(12, 1fr);|padding-left: 32px;|padding-right: 32px;",
+		ClassName: "grid__style--desktop-columns",
+		Type:      "grid",
+	}
+	if token.Value != want.Value || token.ClassName != want.ClassName || token.Type != want.Type || token.CssSelector() != ":root" {
+		t.Errorf("%+v = %+v; want %+v", "S:1", token, want)
+	}
+
+	css, err := f.GenerateTokens(map[string]figma.Token{"S:1": token}, CssEmitter{})
+	if err != nil || !strings.Contains(css, ".grid__style--desktop-columns {") {
+		t.Errorf("GenerateTokens = %v, %v; want grid class", css, err)
+	}
+}
+
+func TestParseComponentsLayoutGrids(t *testing.T) {
+	var file figma.File
+
+	data := []byte(`{"document": {"children": [{"type": "CANVAS", "children": [{
+		"id": "1:2",
+		"name": "Page",
+		"type": "COMPONENT",
+		"layoutGrids": [{"pattern": "COLUMNS", "alignment": "STRETCH", "count": 12, "gutterSize": 24}]
+	}]}]}, "components": {"1:2": {"name": "Page"}}}`)
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	if ans := f.ParseComponents(file, nil)["1:2"].Styles; ans["display"] != "" || ans["grid-template-columns"] != "" {
+		t.Errorf("Styles = %v; want layout grids ignored by default", ans)
+	}
+
+	f.LayoutGrids = true
+	if ans := f.ParseComponents(file, nil)["1:2"].Styles; ans["display"] != "grid" || ans["grid-template-columns"] != "repeat(12, 1fr)" {
+		t.Errorf("Styles = %v; want grid rules", ans)
+	}
+}
+
+func TestParseComponentsImageOpacity(t *testing.T) {
+	var file figma.File
+	var logs bytes.Buffer
+
+	data := []byte(`{"document": {"children": [{"type": "CANVAS", "children": [{
+		"id": "1:2",
+		"name": "Avatar",
+		"type": "COMPONENT",
+		"fills": [{"type": "IMAGE", "imageRef": "abc123", "scaleMode": "FILL", "opacity": 0.5}]
+	}]}]}, "components": {"1:2": {"name": "Avatar"}}}`)
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{
+		Images: figma.LocalImages("images", ".png"),
+		Logger: slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	if ans := f.ParseComponents(file, nil)["1:2"].Styles["background"]; ans != `url("images/abc123.png") center / cover no-repeat` {
+		t.Errorf("background = %v; want the image", ans)
+	}
+
+	if !strings.Contains(logs.String(), "unsupported image fill opacity skipped") {
+		t.Errorf("ParseComponents logs = %v; want image fill opacity skipped", logs.String())
+	}
+}
@@ -0,0 +1,266 @@
+package figo
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vpaulo/figo/figma"
+	fg "github.com/vpaulo/figo/figma"
+)
+
+func (figma *Figma) getImageFillsUri() (string, error) {
+	component_url := figma.baseUrl() + `/v1/files/{{.FILE_KEY}}/images`
+	t := fg.CreateTmpl("figma_uri", component_url)
+
+	var result bytes.Buffer
+
+	err := t.Execute(&result, figma)
+	if err != nil {
+		return "", err
+	}
+
+	return result.String(), nil
+}
+
+func (figma *Figma) getImagesUri(ids []string, format fg.ImageFormat, scale float64) (string, error) {
+	component_url := figma.baseUrl() + `/v1/images/{{.FILE_KEY}}`
+	t := fg.CreateTmpl("figma_uri", component_url)
+
+	var result bytes.Buffer
+
+	err := t.Execute(&result, figma)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("ids", strings.Join(ids, ","))
+	if format != "" {
+		query.Set("format", strings.ToLower(string(format)))
+	}
+	if scale != 0.0 {
+		query.Set("scale", strconv.FormatFloat(scale, 'f', -1, 64))
+	}
+
+	return result.String() + "?" + query.Encode(), nil
+}
+
+// GetImageFills returns the download urls of every image fill in the file, keyed by ImageRef.
+func (f *Figma) GetImageFills() (figma.ImageFills, error) {
+	return f.GetImageFillsContext(context.Background())
+}
+
+func (f *Figma) GetImageFillsContext(ctx context.Context) (figma.ImageFills, error) {
+	var images figma.ImageFills
+
+	uri, uriError := f.getImageFillsUri()
+	if uriError != nil {
+		return images, uriError
+	}
+
+	body, httpError := f.get(ctx, uri)
+	if httpError != nil {
+		return images, httpError
+	}
+
+	if unmarshallingError := json.Unmarshal(body, &images); unmarshallingError != nil {
+		return images, unmarshallingError
+	}
+
+	return images, nil
+}
+
+// GetImages renders the given nodes and returns their download urls, keyed by node ID.
+// An empty format or scale uses the API defaults (PNG at scale 1).
+func (f *Figma) GetImages(ids []string, format figma.ImageFormat, scale float64) (figma.Images, error) {
+	return f.GetImagesContext(context.Background(), ids, format, scale)
+}
+
+func (f *Figma) GetImagesContext(ctx context.Context, ids []string, format figma.ImageFormat, scale float64) (figma.Images, error) {
+	var images figma.Images
+
+	uri, uriError := f.getImagesUri(ids, format, scale)
+	if uriError != nil {
+		return images, uriError
+	}
+
+	body, httpError := f.get(ctx, uri)
+	if httpError != nil {
+		return images, httpError
+	}
+
+	if unmarshallingError := json.Unmarshal(body, &images); unmarshallingError != nil {
+		return images, unmarshallingError
+	}
+
+	if images.Err != "" {
+		return images, fmt.Errorf("error rendering images: %v", images.Err)
+	}
+
+	return images, nil
+}
+
+// DownloadImages saves each url to dir, named after its key and extension,
+// and returns the saved file paths by key. Empty urls are skipped.
+func (f *Figma) DownloadImages(images map[string]string, dir string, extension string) (map[string]string, error) {
+	return f.DownloadImagesContext(context.Background(), images, dir, extension)
+}
+
+func (f *Figma) DownloadImagesContext(ctx context.Context, images map[string]string, dir string, extension string) (map[string]string, error) {
+	paths := make(map[string]string)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return paths, err
+	}
+
+	for key, uri := range images {
+		if uri == "" {
+			continue
+		}
+
+		path := filepath.Join(dir, fileName(key)+extension)
+		if err := f.download(ctx, uri, path); err != nil {
+			return paths, err
+		}
+
+		paths[key] = path
+	}
+
+	return paths, nil
+}
+
+// ExportAssets renders every node with export settings and saves the assets to dir,
+// the files are named after the node and the export setting suffix. Nodes with the same
+// name, after the first one, also have their node ID in the file name.
+func (f *Figma) ExportAssets(file figma.File, dir string) ([]string, error) {
+	return f.ExportAssetsContext(context.Background(), file, dir)
+}
+
+func (f *Figma) ExportAssetsContext(ctx context.Context, file figma.File, dir string) ([]string, error) {
+	var paths []string
+	exports := make(map[exportKey][]exportAsset)
+
+	collectExports(file.Document, exports)
+
+	used := make(map[string]bool)
+	keys := slices.SortedFunc(maps.Keys(exports), func(a, b exportKey) int {
+		return cmp.Or(strings.Compare(string(a.Format), string(b.Format)), cmp.Compare(a.Scale, b.Scale))
+	})
+
+	for _, key := range keys {
+		assets := exports[key]
+		extension := "." + strings.ToLower(string(key.Format))
+
+		var ids []string
+		for _, asset := range assets {
+			ids = append(ids, asset.ID)
+		}
+
+		images, err := f.GetImagesContext(ctx, ids, key.Format, key.Scale)
+		if err != nil {
+			return paths, err
+		}
+
+		files := make(map[string]string)
+		for _, asset := range assets {
+			name := asset.Name + asset.Suffix
+			if used[name+extension] {
+				name = asset.Name + "-" + fileName(asset.ID) + asset.Suffix
+			}
+			used[name+extension] = true
+			files[name] = images.Images[asset.ID]
+		}
+
+		saved, err := f.DownloadImagesContext(ctx, files, dir, extension)
+		if err != nil {
+			return paths, err
+		}
+
+		for _, path := range saved {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
+type exportKey struct {
+	Format figma.ImageFormat
+	Scale  float64
+}
+
+type exportAsset struct {
+	ID     string
+	Name   string
+	Suffix string
+}
+
+func collectExports(node figma.Node, exports map[exportKey][]exportAsset) {
+	for _, setting := range node.ExportSettings {
+		format := setting.Format
+		if format == "" {
+			format = fg.ImageFormatPNG
+		}
+
+		key := exportKey{Format: format, Scale: exportScale(node, setting.Constraint)}
+		asset := exportAsset{ID: node.ID, Name: fg.ToKebabCase(node.Name), Suffix: setting.Suffix}
+		exports[key] = append(exports[key], asset)
+	}
+
+	for _, child := range node.Children {
+		collectExports(child, exports)
+	}
+}
+
+// The images endpoint only takes a scale, width and height constraints are converted using the node size.
+func exportScale(node figma.Node, constraint figma.Constraint) float64 {
+	switch constraint.Type {
+	case fg.ConstraintTypeWidth:
+		if node.AbsoluteBoundingBox.Width != 0.0 {
+			return constraint.Value / node.AbsoluteBoundingBox.Width
+		}
+	case fg.ConstraintTypeHeight:
+		if node.AbsoluteBoundingBox.Height != 0.0 {
+			return constraint.Value / node.AbsoluteBoundingBox.Height
+		}
+	case fg.ConstraintTypeScale:
+		return constraint.Value
+	}
+	return 1.0
+}
+
+// Node IDs use ":" which is not valid in every file system.
+func fileName(name string) string {
+	return strings.NewReplacer(":", "-", ";", "-", "/", "-", "\\", "-").Replace(name)
+}
+
+// download saves an image url to path.
+func (f *Figma) download(ctx context.Context, uri string, path string) error {
+	httpResp, httpError := f.do(ctx, uri, false)
+	if httpError != nil {
+		return httpError
+	}
+
+	defer httpResp.Body.Close()
+
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	_, err = io.Copy(out, httpResp.Body)
+	return err
+}
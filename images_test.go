@@ -0,0 +1,160 @@
+package figo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/vpaulo/figo/figma"
+)
+
+func testServer(t *testing.T, handler http.HandlerFunc) string {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestGetImageFills(t *testing.T) {
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/files/KEY/images" || r.Header.Get("X-Figma-Token") != "TOKEN" {
+			t.Errorf("unexpected request %v %v", r.URL, r.Header)
+		}
+		w.Write([]byte(`{"error":false,"status":200,"meta":{"images":{"ref1":"https://example.com/ref1"}}}`))
+	})
+
+	f := Figma{FILE_KEY: "KEY", API_KEY: "TOKEN", BaseUrl: url}
+	images, err := f.GetImageFills()
+	if err != nil {
+		t.Fatalf("GetImageFills error: %v", err)
+	}
+
+	if images.Meta.Images["ref1"] != "https://example.com/ref1" {
+		t.Errorf("GetImageFills = %+v", images)
+	}
+}
+
+func TestGetImages(t *testing.T) {
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/v1/images/KEY" || query.Get("ids") != "1:2,3:4" || query.Get("format") != "svg" || query.Get("scale") != "2" {
+			t.Errorf("unexpected request %v", r.URL)
+		}
+		w.Write([]byte(`{"err":null,"images":{"1:2":"https://example.com/1-2.svg","3:4":null}}`))
+	})
+
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url}
+	images, err := f.GetImages([]string{"1:2", "3:4"}, figma.ImageFormateSVG, 2.0)
+	if err != nil {
+		t.Fatalf("GetImages error: %v", err)
+	}
+
+	if images.Images["1:2"] != "https://example.com/1-2.svg" || images.Images["3:4"] != "" {
+		t.Errorf("GetImages = %+v", images)
+	}
+}
+
+func TestExportAssets(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/images/KEY":
+			if r.Header.Get("X-Figma-Token") != "TOKEN" {
+				t.Errorf("missing token for %v", r.URL)
+			}
+			w.Write([]byte(`{"images":{"1:2":"` + server.URL + `/assets/1-2"}}`))
+		case "/assets/1-2":
+			if r.Header.Get("X-Figma-Token") != "" {
+				t.Errorf("token sent to image url %v", r.URL)
+			}
+			w.Write([]byte("PNG"))
+		default:
+			t.Errorf("unexpected request %v", r.URL)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	file := figma.File{
+		Document: figma.Node{
+			Children: []figma.Node{
+				{
+					ID:   "1:2",
+					Name: "Icon Close",
+					ExportSettings: []figma.ExportSetting{
+						{
+							Suffix:     "@2x",
+							Format:     figma.ImageFormatPNG,
+							Constraint: figma.Constraint{Type: figma.ConstraintTypeScale, Value: 2.0},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	f := Figma{FILE_KEY: "KEY", API_KEY: "TOKEN", BaseUrl: server.URL}
+	paths, err := f.ExportAssets(file, dir)
+	if err != nil {
+		t.Fatalf("ExportAssets error: %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "icon-close@2x.png")}
+	if !slices.Equal(paths, want) {
+		t.Errorf("ExportAssets = %v; want %v", paths, want)
+	}
+
+	data, err := os.ReadFile(want[0])
+	if err != nil || string(data) != "PNG" {
+		t.Errorf("ExportAssets saved %q, %v", data, err)
+	}
+}
+
+func TestExportAssetsSameName(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/images/KEY":
+			w.Write([]byte(`{"images":{"1:2":"` + server.URL + `/assets/1-2","1:3":"` + server.URL + `/assets/1-3"}}`))
+		default:
+			w.Write([]byte(r.URL.Path))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	svg := []figma.ExportSetting{{Format: figma.ImageFormateSVG}}
+	file := figma.File{
+		Document: figma.Node{
+			Children: []figma.Node{
+				{ID: "1:2", Name: "Icon", ExportSettings: svg},
+				{ID: "1:3", Name: "Icon", ExportSettings: svg},
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	f := Figma{FILE_KEY: "KEY", BaseUrl: server.URL}
+	paths, err := f.ExportAssets(file, dir)
+	if err != nil {
+		t.Fatalf("ExportAssets error: %v", err)
+	}
+
+	want := map[string]string{
+		filepath.Join(dir, "icon.svg"):     "/assets/1-2",
+		filepath.Join(dir, "icon-1-3.svg"): "/assets/1-3",
+	}
+
+	if len(paths) != len(want) {
+		t.Errorf("ExportAssets = %v; want %v files", paths, len(want))
+	}
+
+	for path, content := range want {
+		data, err := os.ReadFile(path)
+		if err != nil || string(data) != content {
+			t.Errorf("%+v = %q, %v; want %v", path, data, err, content)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package figo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/vpaulo/figo/figma"
+	fg "github.com/vpaulo/figo/figma"
+)
+
+type androidResource struct {
+	Name  string
+	Type  string // color, dimen or float
+	Value string
+}
+
+type swiftEnum struct {
+	Name      string
+	Constants []swiftConstant
+}
+
+type swiftConstant struct {
+	Name  string
+	Type  string
+	Value string
+}
+
+// ExportAndroid writes the color and FLOAT variables as Android resources, values/colors.xml and
+// values/dimens.xml have the default modes and values-night a dark one per collection. Names are the ANDROID
+// code syntax or the collection and variable name in camel case, aliases reference their resource
+// unless it is not exported, e.g. deleted, then they have its value. Unitless FLOAT variables are
+// float items scaled like the CSS ones, e.g. opacities are 0 to 1.
+func (f *Figma) ExportAndroid(variables figma.Variables) (map[string][]byte, error) {
+	meta := variables.Meta
+	resources := make(map[string][]androidResource)
+	var errs []error
+
+	for _, v := range meta.Variables {
+		collection, ok := meta.VariableCollections[v.VariableCollectionId]
+		if !f.exported(meta, v) || !ok {
+			continue
+		}
+
+		if v.ResolvedType != fg.ResolvedTypeColor && v.ResolvedType != fg.ResolvedTypeFloat {
+			f.logger().Debug("unsupported android variable type skipped", "variable", v.Name, "type", v.ResolvedType)
+			continue
+		}
+
+		dark := darkMode(collection)
+		for _, mode := range collection.Modes {
+			folder := "values"
+			if mode.ModeId != collection.DefaultModeId {
+				if mode.ModeId != dark {
+					continue // android resources only have qualifiers for night mode
+				}
+				folder = "values-night"
+			}
+
+			value, ok := v.ValuesByMode[mode.ModeId]
+			if !ok {
+				continue
+			}
+
+			resolved, err := meta.Resolve(v, mode.ModeId)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			resource := f.androidVariable(meta, v, value, resolved)
+			file := folder + "/colors.xml"
+			if resource.Type != "color" {
+				file = folder + "/dimens.xml"
+			}
+			resources[file] = append(resources[file], resource)
+		}
+	}
+
+	output := make(map[string][]byte)
+	for file, values := range resources {
+		slices.SortFunc(values, func(a, b androidResource) int { return strings.Compare(a.Name, b.Name) })
+
+		var out bytes.Buffer
+		tmp := figma.CreateTmpl("android", figma.AndroidResourcesTemplate)
+		if err := tmp.Execute(&out, values); err != nil {
+			return output, err
+		}
+		output[file] = out.Bytes()
+	}
+
+	return output, errors.Join(errs...)
+}
+
+// ExportSwift writes the color and FLOAT variables as SwiftUI Color and CGFloat constants, in an enum
+// per collection. FLOAT values are scaled like the CSS ones, e.g. opacities are 0 to 1. Names are the
+// iOS code syntax or the variable name in camel case. Colors with a dark mode change with the interface
+// style, a collection has a single dark mode, see darkMode.
+func (f *Figma) ExportSwift(variables figma.Variables) ([]byte, error) {
+	meta := variables.Meta
+	enums := make(map[string]*swiftEnum)
+	var errs []error
+
+	for _, v := range meta.Variables {
+		collection, ok := meta.VariableCollections[v.VariableCollectionId]
+		if !f.exported(meta, v) || !ok {
+			continue
+		}
+
+		if v.ResolvedType != fg.ResolvedTypeColor && v.ResolvedType != fg.ResolvedTypeFloat {
+			f.logger().Debug("unsupported swift variable type skipped", "variable", v.Name, "type", v.ResolvedType)
+			continue
+		}
+
+		value, err := meta.Resolve(v, collection.DefaultModeId)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		constant := swiftConstant{Name: swiftName(v), Value: f.swiftValue(meta, v, v.ValuesByMode[collection.DefaultModeId], value)}
+		if value.Kind == fg.VariableValueKindFloat {
+			constant.Type = "CGFloat"
+		}
+
+		mode := darkMode(collection)
+		if dark, ok := v.ValuesByMode[mode]; ok && mode != "" && value.Kind == fg.VariableValueKindColor {
+			if resolved, err := meta.Resolve(v, mode); err != nil {
+				errs = append(errs, err)
+			} else {
+				constant.Value = fmt.Sprintf("SwiftUI.Color(UIColor { $0.userInterfaceStyle == .dark ? UIColor(%v) : UIColor(%v) })", f.swiftValue(meta, v, dark, resolved), constant.Value)
+			}
+		}
+
+		name := swiftEnumName(collection)
+		if enums[name] == nil {
+			enums[name] = &swiftEnum{Name: name}
+		}
+		enums[name].Constants = append(enums[name].Constants, constant)
+	}
+
+	var data []swiftEnum
+	for _, name := range slices.Sorted(maps.Keys(enums)) {
+		enum := *enums[name]
+		slices.SortFunc(enum.Constants, func(a, b swiftConstant) int { return strings.Compare(a.Name, b.Name) })
+		data = append(data, enum)
+	}
+
+	var out bytes.Buffer
+	tmp := figma.CreateTmpl("swift", figma.SwiftTokensTemplate)
+	if err := tmp.Execute(&out, data); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), errors.Join(errs...)
+}
+
+// androidVariable references the resource of aliases to exported variables, other values are the resolved literal.
+func (f *Figma) androidVariable(meta fg.Meta, v fg.Variable, value, resolved fg.VariableValue) androidResource {
+	resource := androidResource{Name: androidName(meta, v), Type: "dimen"}
+
+	switch {
+	case v.ResolvedType == fg.ResolvedTypeColor:
+		resource.Type = "color"
+	case f.variableUnit(v) == "":
+		resource.Type = "float" // unitless
+	}
+
+	alias, ok := f.aliasTarget(meta, value)
+	if !ok {
+		value = resolved
+	}
+
+	switch value.Kind {
+	case fg.VariableValueKindColor:
+		resource.Value = value.Color.HexArgb()
+	case fg.VariableValueKindFloat:
+		number, _ := f.variableFloat(v, value.Float)
+		resource.Value = fmt.Sprint(number)
+		if resource.Type == "dimen" {
+			resource.Value += androidUnit(v)
+		}
+	case fg.VariableValueKindAlias:
+		if resource.Type == "color" {
+			resource.Value = "@color/" + androidName(meta, alias)
+		} else {
+			resource.Value = "@dimen/" + androidName(meta, alias)
+		}
+	}
+
+	return resource
+}
+
+// darkMode is the ID of the mode used for night mode, a collection can have a single one. Modes
+// named "Dark" are picked over others like "Dark HC", then the first dark mode is.
+func darkMode(collection fg.VariableCollection) string {
+	var dark string
+	for _, mode := range collection.Modes {
+		if mode.ModeId == collection.DefaultModeId || colorScheme(mode.Name) != "dark" {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(mode.Name), "dark") {
+			return mode.ModeId
+		}
+		if dark == "" {
+			dark = mode.ModeId
+		}
+	}
+	return dark
+}
+
+// Text sizes use sp so they follow the user font size, other dimensions use dp.
+func androidUnit(v fg.Variable) string {
+	for _, scope := range v.Scopes {
+		if scope == fg.VariableScopeFontSize || scope == fg.VariableScopeLineHeight || scope == fg.VariableScopeLetterSpacing {
+			return "sp"
+		}
+	}
+	return "dp"
+}
+
+// Android resources share one namespace, derived names include the collection.
+func androidName(meta fg.Meta, v fg.Variable) string {
+	if name := codeSyntaxName(v.CodeSyntax.Android); name != "" {
+		return name
+	}
+	return fg.ToCamelCase(meta.VariableCollections[v.VariableCollectionId].Name + " " + v.Name)
+}
+
+func swiftName(v fg.Variable) string {
+	if name := codeSyntaxName(v.CodeSyntax.Ios); name != "" {
+		return name
+	}
+	return fg.ToCamelCase(v.Name)
+}
+
+// Enums have a suffix so collections like "Color" do not hide the SwiftUI types.
+func swiftEnumName(collection fg.VariableCollection) string {
+	return fg.ToPascalCase(collection.Name) + "Tokens"
+}
+
+func (f *Figma) swiftValue(meta fg.Meta, v fg.Variable, value, resolved fg.VariableValue) string {
+	alias, ok := f.aliasTarget(meta, value)
+	if !ok {
+		value = resolved
+	}
+
+	switch value.Kind {
+	case fg.VariableValueKindColor:
+		c := value.Color
+		return fmt.Sprintf("SwiftUI.Color(red: %v, green: %v, blue: %v, opacity: %v)",
+			fg.RoundToDecimals(c.Red, 4), fg.RoundToDecimals(c.Green, 4), fg.RoundToDecimals(c.Blue, 4), fg.RoundToDecimals(c.Alpha, 4))
+	case fg.VariableValueKindFloat:
+		number, _ := f.variableFloat(v, value.Float)
+		return fmt.Sprint(number)
+	case fg.VariableValueKindAlias:
+		return swiftEnumName(meta.VariableCollections[alias.VariableCollectionId]) + "." + swiftName(alias)
+	}
+	return ""
+}
+
+// Code syntax names can be written with their type, e.g. R.color.primary or Color.primary.
+func codeSyntaxName(name string) string {
+	name = strings.TrimSpace(name)
+	if i := strings.LastIndexAny(name, "./"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
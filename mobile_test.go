@@ -0,0 +1,219 @@
+package figo
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/vpaulo/figo/figma"
+)
+
+func TestExportAndroid(t *testing.T) {
+	var variables figma.Variables
+	if err := json.Unmarshal(aliasedVariables, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	files, err := f.ExportAndroid(variables)
+	if !errors.Is(err, figma.ErrAliasNotFound) {
+		t.Errorf("ExportAndroid error = %v; want alias not found", err)
+	}
+
+	want := map[string]string{
+		"values/colors.xml":       `<color name="colorDanger">@color/primitivesRed500</color>`,
+		"values/dimens.xml":       `<dimen name="primitivesSpaceSmall">4dp</dimen>`,
+		"values-night/colors.xml": `<color name="colorDanger">#FF800000</color>`,
+	}
+
+	if len(files) != len(want) {
+		t.Errorf("ExportAndroid files = %v; want %v", len(files), len(want))
+	}
+
+	for name, content := range want {
+		if !strings.Contains(string(files[name]), content) {
+			t.Errorf("%+v = %s; want %v", name, files[name], content)
+		}
+	}
+}
+
+func TestExportSwift(t *testing.T) {
+	var variables figma.Variables
+	if err := json.Unmarshal(aliasedVariables, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	ans, _ := f.ExportSwift(variables)
+
+	want := []string{
+		"public enum PrimitivesTokens {",
+		"public static let spaceSmall: CGFloat = 4",
+		"public static let red500 = SwiftUI.Color(red: 1, green: 0, blue: 0, opacity: 1)",
+		"? UIColor(SwiftUI.Color(red: 0.5, green: 0, blue: 0, opacity: 1)) : UIColor(PrimitivesTokens.red500)",
+	}
+
+	for _, content := range want {
+		if !strings.Contains(string(ans), content) {
+			t.Errorf("ExportSwift = %s; want %v", ans, content)
+		}
+	}
+}
+
+// The dark mode of Link aliases a deleted variable.
+var deletedAliasVariables = []byte(`{"meta": {
+	"variableCollections": {
+		"C:1": {"id": "C:1", "name": "Color", "defaultModeId": "1:0", "modes": [{"modeId": "1:0", "name": "Light"}, {"modeId": "1:1", "name": "Dark"}]}
+	},
+	"variables": {
+		"V:1": {"id": "V:1", "name": "Blue", "variableCollectionId": "C:1", "resolvedType": "COLOR", "valuesByMode": {"1:0": {"r": 0, "g": 0, "b": 1, "a": 1}, "1:1": {"r": 0, "g": 0, "b": 1, "a": 1}}},
+		"V:2": {"id": "V:2", "name": "Old", "variableCollectionId": "C:1", "resolvedType": "COLOR", "deletedButReferenced": true, "valuesByMode": {"1:0": {"r": 1, "g": 0, "b": 0, "a": 1}, "1:1": {"r": 1, "g": 0, "b": 0, "a": 1}}},
+		"V:3": {"id": "V:3", "name": "Link", "variableCollectionId": "C:1", "resolvedType": "COLOR", "codeSyntax": {"WEB": "--link"}, "valuesByMode": {"1:0": {"type": "VARIABLE_ALIAS", "id": "V:1"}, "1:1": {"type": "VARIABLE_ALIAS", "id": "V:2"}}}
+	}
+}}`)
+
+func TestExportMobileDeletedAlias(t *testing.T) {
+	var variables figma.Variables
+	if err := json.Unmarshal(deletedAliasVariables, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	files, err := f.ExportAndroid(variables)
+	if err != nil {
+		t.Fatalf("ExportAndroid error = %v", err)
+	}
+
+	want := map[string]string{
+		"values/colors.xml":       `<color name="colorLink">@color/colorBlue</color>`,
+		"values-night/colors.xml": `<color name="colorLink">#FFFF0000</color>`,
+	}
+
+	for name, content := range want {
+		if !strings.Contains(string(files[name]), content) || strings.Contains(string(files[name]), "colorOld") {
+			t.Errorf("%+v = %s; want %v", name, files[name], content)
+		}
+	}
+
+	swift, err := f.ExportSwift(variables)
+	if err != nil {
+		t.Fatalf("ExportSwift error = %v", err)
+	}
+
+	content := "? UIColor(SwiftUI.Color(red: 1, green: 0, blue: 0, opacity: 1)) : UIColor(ColorTokens.blue)"
+	if !strings.Contains(string(swift), content) || strings.Contains(string(swift), "ColorTokens.old") {
+		t.Errorf("ExportSwift = %s; want %v", swift, content)
+	}
+}
+
+func TestExportMobileFloats(t *testing.T) {
+	var variables figma.Variables
+
+	data := []byte(`{"meta": {
+		"variableCollections": {
+			"C:1": {"id": "C:1", "name": "Size", "defaultModeId": "1:0", "modes": [{"modeId": "1:0", "name": "Default"}]}
+		},
+		"variables": {
+			"V:1": {"id": "V:1", "name": "Opacity/Half", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["OPACITY"], "valuesByMode": {"1:0": 50}},
+			"V:2": {"id": "V:2", "name": "Bold", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["FONT_WEIGHT"], "valuesByMode": {"1:0": 700}},
+			"V:3": {"id": "V:3", "name": "Gap", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["GAP"], "valuesByMode": {"1:0": 8}}
+		}
+	}}`)
+
+	if err := json.Unmarshal(data, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	files, _ := f.ExportAndroid(variables)
+
+	for _, content := range []string{
+		`<item name="sizeOpacityHalf" format="float" type="dimen">0.5</item>`,
+		`<item name="sizeBold" format="float" type="dimen">700</item>`,
+		`<dimen name="sizeGap">8dp</dimen>`,
+	} {
+		if !strings.Contains(string(files["values/dimens.xml"]), content) {
+			t.Errorf("values/dimens.xml = %s; want %v", files["values/dimens.xml"], content)
+		}
+	}
+
+	swift, _ := f.ExportSwift(variables)
+
+	for _, content := range []string{
+		"public static let opacityHalf: CGFloat = 0.5",
+		"public static let bold: CGFloat = 700",
+		"public static let gap: CGFloat = 8",
+	} {
+		if !strings.Contains(string(swift), content) {
+			t.Errorf("ExportSwift = %s; want %v", swift, content)
+		}
+	}
+}
+
+func TestDarkMode(t *testing.T) {
+	tests := map[string]struct {
+		modes []string
+		want  string
+	}{
+		"light only":    {[]string{"Light"}, ""},
+		"dark":          {[]string{"Light", "Dark"}, "2"},
+		"high contrast": {[]string{"Light", "Dark HC", "Dark"}, "3"},
+		"first dark":    {[]string{"Light", "Dark HC", "Dark dimmed"}, "2"},
+		"default dark":  {[]string{"Dark", "Dark HC"}, "2"},
+	}
+
+	for name, test := range tests {
+		collection := figma.VariableCollection{DefaultModeId: "1"}
+		for i, mode := range test.modes {
+			collection.Modes = append(collection.Modes, figma.Modes{ModeId: strconv.Itoa(i + 1), Name: mode})
+		}
+
+		ans := darkMode(collection)
+		if ans != test.want {
+			t.Errorf("%+v = %v; want %v", name, ans, test.want)
+		}
+	}
+}
+
+func TestExportAndroidDarkModes(t *testing.T) {
+	var variables figma.Variables
+
+	data := []byte(`{"meta": {
+		"variableCollections": {
+			"C:1": {"id": "C:1", "name": "Color", "defaultModeId": "1:0", "modes": [{"modeId": "1:0", "name": "Light"}, {"modeId": "1:1", "name": "Dark HC"}, {"modeId": "1:2", "name": "Dark"}]}
+		},
+		"variables": {
+			"V:1": {"id": "V:1", "name": "Text", "variableCollectionId": "C:1", "resolvedType": "COLOR", "valuesByMode": {"1:0": {"r": 0, "g": 0, "b": 0, "a": 1}, "1:1": {"r": 1, "g": 1, "b": 1, "a": 1}, "1:2": {"r": 0.8, "g": 0.8, "b": 0.8, "a": 1}}}
+		}
+	}}`)
+
+	if err := json.Unmarshal(data, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	files, _ := f.ExportAndroid(variables)
+
+	night := string(files["values-night/colors.xml"])
+	if strings.Count(night, "colorText") != 1 || !strings.Contains(night, "#FFCCCCCC") {
+		t.Errorf("values-night/colors.xml = %s; want colorText once with the Dark mode", night)
+	}
+}
+
+func TestCodeSyntaxName(t *testing.T) {
+	tests := map[string]string{
+		"":                 "",
+		"primary":          "primary",
+		"R.color.primary":  "primary",
+		"Color.brand/main": "main",
+	}
+
+	for name, want := range tests {
+		ans := codeSyntaxName(name)
+		if ans != want {
+			t.Errorf("%+v = %v; want %v", name, ans, want)
+		}
+	}
+}
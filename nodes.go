@@ -0,0 +1,83 @@
+package figo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vpaulo/figo/figma"
+	fg "github.com/vpaulo/figo/figma"
+)
+
+type NodesOptions struct {
+	Depth    int    // How deep into the node trees to go, 0 returns the full subtrees
+	Geometry bool   // Include vector paths, sets geometry=paths
+	Version  string // File version to read, defaults to Figma.Version
+}
+
+func (figma *Figma) getNodesUri(ids []string, options NodesOptions) (string, error) {
+	component_url := figma.baseUrl() + `/v1/files/{{.FILE_KEY}}/nodes`
+	t := fg.CreateTmpl("figma_uri", component_url)
+
+	var result bytes.Buffer
+
+	err := t.Execute(&result, figma)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("ids", strings.Join(ids, ","))
+	if options.Depth > 0 {
+		query.Set("depth", strconv.Itoa(options.Depth))
+	}
+	if options.Geometry {
+		query.Set("geometry", "paths")
+	}
+	if options.Version != "" {
+		query.Set("version", options.Version)
+	} else if figma.Version != "" {
+		query.Set("version", figma.Version)
+	}
+
+	return result.String() + "?" + query.Encode(), nil
+}
+
+// GetNodes fetches only the given nodes and their components, component sets and styles,
+// use FileNodes.File to parse them like a full file.
+func (f *Figma) GetNodes(ids []string, options NodesOptions) (figma.FileNodes, error) {
+	return f.GetNodesContext(context.Background(), ids, options)
+}
+
+func (f *Figma) GetNodesContext(ctx context.Context, ids []string, options NodesOptions) (figma.FileNodes, error) {
+	var nodes figma.FileNodes
+
+	uri, uriError := f.getNodesUri(ids, options)
+	if uriError != nil {
+		return nodes, uriError
+	}
+
+	body, httpError := f.getCached(ctx, uri)
+	if httpError != nil {
+		return nodes, httpError
+	}
+
+	return f.parseNodes(body)
+}
+
+func (f *Figma) parseNodes(data []byte) (figma.FileNodes, error) {
+	var nodes figma.FileNodes
+	start := time.Now()
+
+	if unmarshallingError := json.Unmarshal(data, &nodes); unmarshallingError != nil {
+		return nodes, unmarshallingError
+	}
+
+	f.logger().Debug("figma nodes parsed", "nodes", len(nodes.Nodes), "bytes", len(data), "duration", time.Since(start))
+
+	return nodes, nil
+}
@@ -0,0 +1,34 @@
+package figo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetNodes(t *testing.T) {
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/v1/files/KEY/nodes" || query.Get("ids") != "1:2" || query.Get("depth") != "2" || query.Get("geometry") != "paths" || query.Get("version") != "42" {
+			t.Errorf("unexpected request %v", r.URL)
+		}
+		w.Write([]byte(`{"name":"Design System","version":"42","nodes":{"1:2":{"document":{"id":"1:2","name":"Button","type":"COMPONENT","children":[{"id":"1:3","name":"Label","type":"FRAME"}]},"components":{"1:2":{"key":"abc","name":"Button"}},"styles":{}}}}`))
+	})
+
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url, Prefix: "vp"}
+	nodes, err := f.GetNodes([]string{"1:2"}, NodesOptions{Depth: 2, Geometry: true, Version: "42"})
+	if err != nil {
+		t.Fatalf("GetNodes error: %v", err)
+	}
+
+	node := nodes.Nodes["1:2"].Document
+	if node.Name != "Button" || node.Visible == nil || !*node.Visible || node.Children[0].Visible == nil {
+		t.Errorf("GetNodes node = %+v; want defaults set", node)
+	}
+
+	// the nodes can be parsed like a full file
+	file := nodes.File()
+	components := f.ParseComponents(file, f.ParseTokens(file))
+	if components["1:2"].Name != "vp-button" || len(components["1:2"].Children) != 1 {
+		t.Errorf("ParseComponents = %+v", components)
+	}
+}
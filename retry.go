@@ -0,0 +1,78 @@
+package figo
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt, 0 disables retries
+	MinBackoff time.Duration // Wait before the first retry, doubled on every retry
+	MaxBackoff time.Duration // Longest wait between attempts, a longer Retry-After gives up instead
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: 1 * time.Second,
+	MaxBackoff: 30 * time.Second,
+}
+
+func (f *Figma) retryPolicy() RetryPolicy {
+	if f.Retry != nil {
+		return *f.Retry
+	}
+	return DefaultRetryPolicy
+}
+
+// Rate limited and server errors are retried, anything else is returned to the caller.
+func isRetryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// backoff returns an exponential wait with jitter, between half and the full backoff for the attempt.
+func (r RetryPolicy) backoff(attempt int) time.Duration {
+	wait := r.MinBackoff << attempt
+	if wait <= 0 || wait > r.MaxBackoff {
+		wait = r.MaxBackoff
+	}
+
+	half := wait / 2
+	if half <= 0 {
+		return wait
+	}
+
+	return half + rand.N(wait-half+1)
+}
+
+// retryAfter parses the Retry-After header, it can be a number of seconds or a http date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
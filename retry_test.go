@@ -0,0 +1,87 @@
+package figo
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryRateLimited(t *testing.T) {
+	attempts := 0
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte(`{"name":"Design System"}`))
+		}
+	})
+
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url, Retry: &RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}}
+	file, err := f.GetData()
+	if err != nil {
+		t.Fatalf("GetData error: %v", err)
+	}
+
+	if file.Name != "Design System" || attempts != 3 {
+		t.Errorf("GetData = %+v after %v attempts", file, attempts)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	attempts := 0
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url, Retry: &RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}}
+	_, err := f.GetData()
+	if err == nil || attempts != 3 {
+		t.Errorf("GetData error = %v after %v attempts; want error after 3 attempts", err, attempts)
+	}
+
+	attempts = 0
+	url = testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	f.BaseUrl = url
+	_, err = f.GetData()
+	if err == nil || attempts != 1 {
+		t.Errorf("GetData error = %v after %v attempts; want error after 1 attempt", err, attempts)
+	}
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	attempts := 0
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url, Retry: &RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Second}}
+	_, err := f.GetData()
+	if !errors.Is(err, ErrRateLimited) || attempts != 1 {
+		t.Errorf("GetData error = %v after %v attempts; want rate limited error after 1 attempt", err, attempts)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, want := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		want *= time.Millisecond
+		wait := policy.backoff(attempt)
+		if wait < want/2 || wait > want {
+			t.Errorf("backoff(%v) = %v; want between %v and %v", attempt, wait, want/2, want)
+		}
+	}
+}
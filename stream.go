@@ -0,0 +1,241 @@
+package figo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vpaulo/figo/figma"
+	fg "github.com/vpaulo/figo/figma"
+)
+
+type DecodeOptions struct {
+	Pages []string              // Page names to decode, every page is decoded when empty
+	Skip  func(figma.Node) bool // Skips a node and its subtree, the node only has the fields listed before its children
+}
+
+func (o *DecodeOptions) skip(node *figma.Node) bool {
+	if node.Type == fg.NodeTypeCanvas && len(o.Pages) > 0 && !slices.Contains(o.Pages, node.Name) {
+		return true
+	}
+	return o.Skip != nil && o.Skip(*node)
+}
+
+// StreamData fetches the file like GetData, but decodes the response while it is read
+// and drops skipped pages and nodes instead of holding the whole document in memory.
+func (f *Figma) StreamData(options DecodeOptions) (figma.File, error) {
+	return f.StreamDataContext(context.Background(), options)
+}
+
+func (f *Figma) StreamDataContext(ctx context.Context, options DecodeOptions) (figma.File, error) {
+	var file figma.File
+
+	uri, uriError := f.getUri()
+	if uriError != nil {
+		return file, uriError
+	}
+
+	// cached responses are already on disk, they are read whole and decoded the same way
+	if f.Cache != nil {
+		body, httpError := f.getCached(ctx, uri)
+		if httpError != nil {
+			return file, httpError
+		}
+		return f.DecodeFile(bytes.NewReader(body), options)
+	}
+
+	httpResp, httpError := f.do(ctx, uri, true)
+	if httpError != nil {
+		return file, httpError
+	}
+
+	defer httpResp.Body.Close()
+
+	return f.DecodeFile(httpResp.Body, options)
+}
+
+func (f *Figma) StreamDataFromFile(path string, options DecodeOptions) (figma.File, error) {
+	var file figma.File
+
+	data, err := os.Open(path)
+	if err != nil {
+		return file, err
+	}
+
+	defer data.Close()
+
+	return f.DecodeFile(data, options)
+}
+
+// DecodeFile decodes a file response with a streaming decoder, only the nodes that are kept are allocated.
+func (f *Figma) DecodeFile(r io.Reader, options DecodeOptions) (figma.File, error) {
+	var file figma.File
+	start := time.Now()
+	dec := json.NewDecoder(r)
+
+	err := decodeObject(dec, reflect.ValueOf(&file).Elem(), func(key string) (bool, error) {
+		if key != "document" {
+			return false, nil
+		}
+		document, _, err := decodeNode(dec, &options)
+		file.Document = document
+		return true, err
+	})
+	if err != nil {
+		return file, err
+	}
+
+	f.logger().Debug("figma file decoded", "name", file.Name, "pages", len(file.Document.Children), "duration", time.Since(start))
+
+	return file, nil
+}
+
+// decodeNode decodes a node, its children are decoded one by one so skipped subtrees are never allocated.
+// Figma lists the node id, name and type before the children, so they are known when deciding to skip.
+func decodeNode(dec *json.Decoder, options *DecodeOptions) (figma.Node, bool, error) {
+	node := figma.DefaultNode()
+	checked := false
+	keep := true
+
+	err := decodeObject(dec, reflect.ValueOf(&node).Elem(), func(key string) (bool, error) {
+		if key != "children" {
+			return false, nil
+		}
+
+		checked = true
+		if options.skip(&node) {
+			keep = false
+			return true, skipValue(dec)
+		}
+
+		if err := expectDelim(dec, '['); err != nil {
+			return true, err
+		}
+
+		node.Children = []figma.Node{}
+
+		for dec.More() {
+			child, keepChild, err := decodeNode(dec, options)
+			if err != nil {
+				return true, err
+			}
+			if keepChild {
+				node.Children = append(node.Children, child)
+			}
+		}
+
+		return true, expectDelim(dec, ']')
+	})
+
+	if !checked && options.skip(&node) {
+		keep = false
+	}
+
+	return node, keep, err
+}
+
+// decodeObject decodes a json object into the fields of v, keys are first given to custom
+// and the ones it does not handle are decoded into the field with the same json name.
+func decodeObject(dec *json.Decoder, v reflect.Value, custom func(key string) (bool, error)) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	fields := jsonFields(v.Type())
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		key, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("unexpected json token %v", token)
+		}
+
+		handled, err := custom(key)
+		if err != nil {
+			return err
+		}
+		if handled {
+			continue
+		}
+
+		if index, ok := fields[key]; ok {
+			if err := dec.Decode(v.Field(index).Addr().Interface()); err != nil {
+				return err
+			}
+		} else if err := skipValue(dec); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+var jsonFieldsCache sync.Map // reflect.Type to map[string]int
+
+// jsonFields maps the json names of a struct to its field indexes.
+func jsonFields(t reflect.Type) map[string]int {
+	if fields, ok := jsonFieldsCache.Load(t); ok {
+		return fields.(map[string]int)
+	}
+
+	fields := make(map[string]int)
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		if name != "-" {
+			fields[name] = i
+		}
+	}
+
+	jsonFieldsCache.Store(t, fields)
+	return fields
+}
+
+// skipValue reads the next json value token by token without keeping it.
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("unexpected json token %v, want %v", token, delim)
+	}
+
+	return nil
+}
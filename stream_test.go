@@ -0,0 +1,96 @@
+package figo
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/vpaulo/figo/figma"
+)
+
+const testFile = "tmp/original_output.json"
+
+func TestStreamDataFromFile(t *testing.T) {
+	f := Figma{}
+	want, err := f.GetDataFromFile(testFile)
+	if err != nil {
+		t.Fatalf("GetDataFromFile error: %v", err)
+	}
+
+	ans, err := f.StreamDataFromFile(testFile, DecodeOptions{})
+	if err != nil {
+		t.Fatalf("StreamDataFromFile error: %v", err)
+	}
+
+	if !reflect.DeepEqual(ans, want) {
+		t.Errorf("StreamDataFromFile does not match GetDataFromFile")
+	}
+
+	ans, err = f.StreamDataFromFile(testFile, DecodeOptions{Pages: []string{"Missing"}})
+	if err != nil || len(ans.Document.Children) != 0 || len(ans.Components) != len(want.Components) {
+		t.Errorf("StreamDataFromFile pages = %v, %v; want no pages", len(ans.Document.Children), err)
+	}
+
+	ans, err = f.StreamDataFromFile(testFile, DecodeOptions{
+		Skip: func(node figma.Node) bool { return node.Type == figma.NodeTypeComponentSet },
+	})
+	if err != nil {
+		t.Fatalf("StreamDataFromFile error: %v", err)
+	}
+
+	for _, page := range ans.Document.Children {
+		for _, node := range page.Children {
+			if node.IsComponentSet() {
+				t.Errorf("StreamDataFromFile kept skipped node %v", node.Name)
+			}
+		}
+	}
+}
+
+// benchmarkRetained reports the heap still used by the decoded file, next to the allocations made while decoding.
+func benchmarkRetained(b *testing.B, decode func() (figma.File, error)) {
+	var before, after runtime.MemStats
+	var retained uint64
+	b.ReportAllocs()
+
+	for b.Loop() {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		file, err := decode()
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		runtime.KeepAlive(file)
+
+		if after.HeapAlloc > before.HeapAlloc {
+			retained = max(retained, after.HeapAlloc-before.HeapAlloc)
+		}
+	}
+
+	b.ReportMetric(float64(retained), "retained-B")
+}
+
+func BenchmarkGetDataFromFile(b *testing.B) {
+	f := Figma{}
+	benchmarkRetained(b, func() (figma.File, error) {
+		return f.GetDataFromFile(testFile)
+	})
+}
+
+func BenchmarkStreamDataFromFile(b *testing.B) {
+	f := Figma{}
+	benchmarkRetained(b, func() (figma.File, error) {
+		return f.StreamDataFromFile(testFile, DecodeOptions{})
+	})
+}
+
+func BenchmarkStreamDataFromFileSkipPages(b *testing.B) {
+	f := Figma{}
+	benchmarkRetained(b, func() (figma.File, error) {
+		return f.StreamDataFromFile(testFile, DecodeOptions{Pages: []string{"Missing"}})
+	})
+}
@@ -0,0 +1,314 @@
+package figo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/vpaulo/figo/figma"
+	fg "github.com/vpaulo/figo/figma"
+)
+
+// ExportStyleDictionary writes tokens as Style Dictionary source files, tokens declared in :root
+// are in tokens.json and the other themes and modes in <theme>.json. Paths start with the
+// category of the token type followed by the collection, if any, and the "/" separated name.
+// Values are converted back from CSS for the platform transforms: var() becomes a reference,
+// colors are hex, sizes and numbers are numbers and light-dark() colors are split into
+// tokens.json and dark.json.
+func (f *Figma) ExportStyleDictionary(tokens map[string]figma.Token) (map[string][]byte, error) {
+	files := make(map[string]fg.StyleDictionary)
+	paths := make(map[string]string) // custom property to token path
+
+	for _, token := range tokens {
+		if token.ClassName == "" && strings.HasPrefix(token.Variable, "--") {
+			paths[token.Variable] = tokenPath(token)
+		}
+	}
+
+	add := func(file string, path string, token figma.Token, value any) {
+		if files[file] == nil {
+			files[file] = make(fg.StyleDictionary)
+		}
+		files[file][path] = fg.StyleDictionaryToken{
+			Value:      value,
+			Type:       token.Type,
+			Comment:    token.Description,
+			Attributes: fg.StyleDictionaryAttributes(path),
+		}
+	}
+
+	for _, token := range tokens {
+		file := "tokens"
+		if token.CssSelector() != ":root" {
+			file = fg.ToKebabCase(token.Theme)
+		}
+
+		path := tokenPath(token)
+
+		if token.ClassName != "" {
+			properties := styleDictionaryProperties(token.Value)
+			for property, value := range properties {
+				if reference, ok := styleDictionaryReference(value, paths); ok {
+					properties[property] = reference
+				}
+			}
+			add(file, path, token, properties)
+			continue
+		}
+
+		if !strings.HasPrefix(token.Variable, "--") {
+			continue // not a custom property, e.g. color-scheme
+		}
+
+		if light, dark, ok := lightDark(token.Value); ok {
+			add(file, path, token, styleDictionaryCss(token.Type, light, paths))
+			add("dark", path, token, styleDictionaryCss(token.Type, dark, paths))
+			continue
+		}
+
+		add(file, path, token, styleDictionaryCss(token.Type, token.Value, paths))
+	}
+
+	return marshalStyleDictionary(files)
+}
+
+func tokenPath(token figma.Token) string {
+	names := strings.Split(token.Name, "/")
+	// style themes are part of the name, see TokenValues
+	if token.Mode == "" && len(names) > 1 && fg.ToKebabCase(names[0]) == token.Theme {
+		names = names[1:]
+	}
+	if token.Collection != "" {
+		names = append([]string{token.Collection}, names...)
+	}
+	return styleDictionaryPath(token.Type, names)
+}
+
+// styleDictionaryCss converts a CSS token value to the value ExportVariablesStyleDictionary writes.
+func styleDictionaryCss(tokenType string, value string, paths map[string]string) any {
+	if reference, ok := styleDictionaryReference(value, paths); ok {
+		return reference
+	}
+
+	if color, ok := rgbaColor(value); ok {
+		return color.Hex()
+	}
+
+	switch tokenType {
+	case "dimension", "number":
+		if number, err := strconv.ParseFloat(strings.TrimSuffix(value, "px"), 64); err == nil {
+			return number
+		}
+	case "string":
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			return unquoted
+		}
+	case "boolean":
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean
+		}
+	}
+
+	return value
+}
+
+// styleDictionaryReference returns the reference of a var() value, e.g. {color.brand.value}.
+func styleDictionaryReference(value string, paths map[string]string) (string, bool) {
+	if !strings.HasPrefix(value, "var(") || !strings.HasSuffix(value, ")") {
+		return "", false
+	}
+	path, ok := paths[strings.TrimSpace(value[4:len(value)-1])]
+	if !ok {
+		return "", false
+	}
+	return "{" + path + ".value}", true
+}
+
+// rgbaColor reads the colors written by figma.Color.Rgba.
+func rgbaColor(value string) (fg.Color, bool) {
+	var red, green, blue int
+	var color fg.Color
+	var rest string
+
+	n, _ := fmt.Sscanf(value, "rgba(%d,%d,%d,%g)%s", &red, &green, &blue, &color.Alpha, &rest)
+	if n != 4 {
+		return color, false
+	}
+
+	color.Red = float64(red) / 255
+	color.Green = float64(green) / 255
+	color.Blue = float64(blue) / 255
+	return color, true
+}
+
+// lightDark splits the values of light-dark(), see ColorScheme.LightDark.
+func lightDark(value string) (string, string, bool) {
+	if !strings.HasPrefix(value, "light-dark(") || !strings.HasSuffix(value, ")") {
+		return "", "", false
+	}
+
+	value = value[len("light-dark(") : len(value)-1]
+	depth := 0
+	for i, r := range value {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				return strings.TrimSpace(value[:i]), strings.TrimSpace(value[i+1:]), true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+// ExportVariablesStyleDictionary writes the variables as Style Dictionary source files, named
+// like the ExportDTCG files. Aliases are written as references, or with their value when the variable
+// is not exported, colors as hex and floats as numbers for the platform transforms. FLOAT variables
+// are typed and scaled by their scopes like in ExportDTCG, only dimensions are in the size category. Variables with code syntax names have them in the codeSyntax attribute.
+func (f *Figma) ExportVariablesStyleDictionary(variables figma.Variables) (map[string][]byte, error) {
+	meta := variables.Meta
+	files := make(map[string]fg.StyleDictionary)
+	var errs []error
+
+	for _, v := range meta.Variables {
+		collection, ok := meta.VariableCollections[v.VariableCollectionId]
+		if !f.exported(meta, v) || !ok {
+			continue
+		}
+
+		tokenType := f.variableType(v)
+		path := f.variablePath(collection, v)
+		attributes := fg.StyleDictionaryAttributes(styleDictionaryPath(tokenType, variableNames(collection, v)))
+
+		for _, mode := range collection.Modes {
+			value, ok := v.ValuesByMode[mode.ModeId]
+			if !ok {
+				continue
+			}
+
+			resolved, err := meta.Resolve(v, mode.ModeId)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			file := fg.ToKebabCase(collection.Name)
+			if mode.ModeId != collection.DefaultModeId {
+				file += "." + fg.ToKebabCase(mode.Name)
+			}
+
+			token := fg.StyleDictionaryToken{
+				Value:      f.styleDictionaryValue(meta, v, value, resolved),
+				Type:       tokenType,
+				Comment:    v.Description,
+				Attributes: maps.Clone(attributes),
+			}
+			if codeSyntax := codeSyntaxNames(v.CodeSyntax); len(codeSyntax) > 0 {
+				token.Attributes["codeSyntax"] = codeSyntax
+			}
+
+			if files[file] == nil {
+				files[file] = make(fg.StyleDictionary)
+			}
+			files[file][path] = token
+		}
+	}
+
+	output, err := marshalStyleDictionary(files)
+	if err != nil {
+		return output, err
+	}
+
+	return output, errors.Join(errs...)
+}
+
+func marshalStyleDictionary(files map[string]fg.StyleDictionary) (map[string][]byte, error) {
+	output := make(map[string][]byte)
+
+	for name, tokens := range files {
+		data, err := json.MarshalIndent(tokens, "", "  ")
+		if err != nil {
+			return output, err
+		}
+		output[name+".json"] = data
+	}
+
+	return output, nil
+}
+
+func styleDictionaryPath(tokenType string, names []string) string {
+	path := []string{fg.StyleDictionaryCategory(tokenType)}
+	for _, name := range names {
+		path = append(path, fg.DesignTokenName(name))
+	}
+	return strings.Join(path, ".")
+}
+
+// variablePath is the WEB code syntax name, so Style Dictionary names the token the way the
+// designers did, or the category, collection and variable name.
+func (f *Figma) variablePath(collection fg.VariableCollection, v fg.Variable) string {
+	if name := webName(v.CodeSyntax); name != "" {
+		return fg.DesignTokenName(name)
+	}
+	return styleDictionaryPath(f.variableType(v), variableNames(collection, v))
+}
+
+func variableNames(collection fg.VariableCollection, v fg.Variable) []string {
+	return append([]string{collection.Name}, strings.Split(v.Name, "/")...)
+}
+
+// styleDictionaryValue references the token of aliases to exported variables, other values are the resolved literal.
+func (f *Figma) styleDictionaryValue(meta fg.Meta, v fg.Variable, value, resolved fg.VariableValue) any {
+	alias, ok := f.aliasTarget(meta, value)
+	if !ok {
+		value = resolved
+	}
+
+	switch value.Kind {
+	case fg.VariableValueKindColor:
+		return value.Color.Hex()
+	case fg.VariableValueKindFloat:
+		number, _ := f.variableFloat(v, value.Float)
+		return number
+	case fg.VariableValueKindString:
+		return value.String
+	case fg.VariableValueKindBoolean:
+		return value.Boolean
+	case fg.VariableValueKindAlias:
+		return "{" + f.variablePath(meta.VariableCollections[alias.VariableCollectionId], alias) + ".value}"
+	}
+	return nil
+}
+
+// Text style tokens are "property: value;" rules separated by "|", see Node.Font.
+func styleDictionaryProperties(rules string) map[string]string {
+	properties := make(map[string]string)
+	for _, rule := range strings.Split(rules, "|") {
+		property, value, ok := strings.Cut(strings.TrimSuffix(rule, ";"), ":")
+		if ok {
+			properties[fg.ToCamelCase(property)] = strings.TrimSpace(value)
+		}
+	}
+	return properties
+}
+
+func codeSyntaxNames(codeSyntax fg.VariableCodeSyntax) map[string]string {
+	names := make(map[string]string)
+	if codeSyntax.Web != "" {
+		names["web"] = codeSyntax.Web
+	}
+	if codeSyntax.Android != "" {
+		names["android"] = codeSyntax.Android
+	}
+	if codeSyntax.Ios != "" {
+		names["ios"] = codeSyntax.Ios
+	}
+	return names
+}
@@ -0,0 +1,176 @@
+package figo
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/vpaulo/figo/figma"
+)
+
+func TestExportVariablesStyleDictionary(t *testing.T) {
+	var variables figma.Variables
+	if err := json.Unmarshal(aliasedVariables, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	danger := variables.Meta.Variables["V:3"]
+	danger.CodeSyntax = figma.VariableCodeSyntax{Web: "--danger", Android: "danger"}
+	variables.Meta.Variables["V:3"] = danger
+
+	f := Figma{}
+	files, err := f.ExportVariablesStyleDictionary(variables)
+	if !errors.Is(err, figma.ErrAliasNotFound) {
+		t.Errorf("ExportVariablesStyleDictionary error = %v; want alias not found", err)
+	}
+
+	var light, dark map[string]figma.StyleDictionaryToken
+	var primitives map[string]map[string]map[string]figma.StyleDictionaryToken
+	json.Unmarshal(files["color.json"], &light)
+	json.Unmarshal(files["color.dark.json"], &dark)
+	json.Unmarshal(files["primitives.json"], &primitives)
+
+	// the WEB code syntax is the token path
+	token := light["danger"]
+	if token.Value != "{color.Primitives.Red.500.value}" || token.Comment != "Errors and destructive actions" {
+		t.Errorf("danger = %+v", token)
+	}
+
+	if token.Attributes["category"] != "color" || token.Attributes["type"] != "Color" || token.Attributes["item"] != "Danger" {
+		t.Errorf("danger attributes = %v", token.Attributes)
+	}
+
+	codeSyntax, _ := token.Attributes["codeSyntax"].(map[string]any)
+	if codeSyntax["web"] != "--danger" || codeSyntax["android"] != "danger" || codeSyntax["ios"] != nil {
+		t.Errorf("danger codeSyntax = %v", codeSyntax)
+	}
+
+	if ans := dark["danger"].Value; ans != "#800000" {
+		t.Errorf("dark danger = %v; want #800000", ans)
+	}
+
+	if _, ok := primitives["size"]["Primitives"]; !ok {
+		t.Errorf("primitives.json = %s; want size category", files["primitives.json"])
+	}
+}
+
+func TestExportVariablesStyleDictionaryDeletedAlias(t *testing.T) {
+	var variables figma.Variables
+	if err := json.Unmarshal(deletedAliasVariables, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	files, err := f.ExportVariablesStyleDictionary(variables)
+	if err != nil {
+		t.Fatalf("ExportVariablesStyleDictionary error: %v", err)
+	}
+
+	var light, dark map[string]figma.StyleDictionaryToken
+	json.Unmarshal(files["color.json"], &light)
+	json.Unmarshal(files["color.dark.json"], &dark)
+
+	if ans := light["link"].Value; ans != "{color.Color.Blue.value}" {
+		t.Errorf("link = %v; want {color.Color.Blue.value}", ans)
+	}
+
+	if ans := dark["link"].Value; ans != "#ff0000" {
+		t.Errorf("dark link = %v; want #ff0000", ans)
+	}
+}
+
+func TestExportVariablesStyleDictionaryScopes(t *testing.T) {
+	var variables figma.Variables
+
+	data := []byte(`{"meta": {
+		"variableCollections": {
+			"C:1": {"id": "C:1", "name": "Size", "defaultModeId": "1:0", "modes": [{"modeId": "1:0", "name": "Default"}]}
+		},
+		"variables": {
+			"V:1": {"id": "V:1", "name": "Gap", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["GAP"], "valuesByMode": {"1:0": 8}},
+			"V:2": {"id": "V:2", "name": "Bold", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["FONT_WEIGHT"], "valuesByMode": {"1:0": 700}},
+			"V:3": {"id": "V:3", "name": "Faded", "variableCollectionId": "C:1", "resolvedType": "FLOAT", "scopes": ["OPACITY"], "valuesByMode": {"1:0": 50}}
+		}
+	}}`)
+
+	if err := json.Unmarshal(data, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{}
+	files, err := f.ExportVariablesStyleDictionary(variables)
+	if err != nil {
+		t.Fatalf("ExportVariablesStyleDictionary error: %v", err)
+	}
+
+	var tokens map[string]map[string]map[string]figma.StyleDictionaryToken
+	json.Unmarshal(files["size.json"], &tokens)
+
+	tests := []struct {
+		category, name string
+		want           figma.StyleDictionaryToken
+	}{
+		{"size", "Gap", figma.StyleDictionaryToken{Type: "dimension", Value: 8.0}},
+		{"font", "Bold", figma.StyleDictionaryToken{Type: "fontWeight", Value: 700.0}},
+		{"number", "Faded", figma.StyleDictionaryToken{Type: "number", Value: 0.5}},
+	}
+
+	for _, test := range tests {
+		ans := tokens[test.category]["Size"][test.name]
+		if ans.Type != test.want.Type || ans.Value != test.want.Value || ans.Attributes["category"] != test.category {
+			t.Errorf("%+v = %+v; want %+v in %v", test.name, ans, test.want, test.category)
+		}
+	}
+}
+
+func TestExportStyleDictionary(t *testing.T) {
+	tokens := map[string]figma.Token{
+		"S:1":     {Name: "Primary/500", Variable: "--primary-500", Value: "rgba(255,0,0,1)", Theme: ":root", Type: "color", Description: "Brand"},
+		"S:2":     {Name: "Dark theme/Primary/500", Variable: "--primary-500", Value: "rgba(128,0,0,1)", Theme: "dark-theme", Type: "color"},
+		"S:3":     {Name: "Heading", Value: "font-family: Inter;|font-size: var(--space-small);", ClassName: "text__style--heading", Type: "typography"},
+		"V:1/1:0": {Name: "Small", Variable: "--space-small", Value: "4px", Theme: "default", Mode: "Default", Selector: ":root", Type: "dimension", Collection: "Space"},
+		"V:2/2:0": {Name: "Danger", Variable: "--color-danger", Value: "var(--primary-500)", Theme: "default", Mode: "Default", Selector: ":root", Type: "color", Collection: "Color"},
+		"V:3/3:0": {Name: "Surface", Variable: "--color-surface", Value: "light-dark(rgba(255,255,255,1), rgba(0,0,0,0.5))", Theme: "light", Mode: "Light", Selector: ":root", Type: "color", Collection: "Color"},
+	}
+
+	f := Figma{}
+	files, err := f.ExportStyleDictionary(tokens)
+	if err != nil {
+		t.Fatalf("ExportStyleDictionary error: %v", err)
+	}
+
+	var root, dark, scheme map[string]map[string]any
+	json.Unmarshal(files["tokens.json"], &root)
+	json.Unmarshal(files["dark-theme.json"], &dark)
+	json.Unmarshal(files["dark.json"], &scheme)
+
+	if len(files) != 3 {
+		t.Errorf("ExportStyleDictionary files = %v; want tokens.json, dark-theme.json and dark.json", len(files))
+	}
+
+	tests := []struct {
+		group map[string]any
+		path  []string
+		want  any
+	}{
+		{root["color"], []string{"Primary", "500", "value"}, "#ff0000"},
+		{root["color"], []string{"Primary", "500", "comment"}, "Brand"},
+		{dark["color"], []string{"Primary", "500", "value"}, "#800000"},
+		{root["color"], []string{"Color", "Danger", "value"}, "{color.Primary.500.value}"},
+		{root["color"], []string{"Color", "Surface", "value"}, "#ffffff"},
+		{scheme["color"], []string{"Color", "Surface", "value"}, "#00000080"},
+		{root["font"], []string{"Heading", "value", "fontSize"}, "{size.Space.Small.value}"},
+		{root["size"], []string{"Space", "Small", "value"}, 4.0},
+	}
+
+	for _, test := range tests {
+		var ans any = test.group
+		for _, name := range test.path {
+			group, _ := ans.(map[string]any)
+			ans = group[name]
+		}
+		if ans != test.want {
+			t.Errorf("%+v = %v; want %v", test.path, ans, test.want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package figo
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/vpaulo/figo/figma"
+	fg "github.com/vpaulo/figo/figma"
+)
+
+// Tailwind theme keys and the namespace of their v4 theme variables, opacity has no v4 namespace.
+var tailwindCategories = []struct {
+	Name      string
+	Namespace string
+}{
+	{"colors", "color"},
+	{"spacing", "spacing"},
+	{"borderRadius", "radius"},
+	{"fontFamily", "font"},
+	{"fontSize", "text"},
+	{"fontWeight", "font-weight"},
+	{"lineHeight", "leading"},
+	{"boxShadow", "shadow"},
+	{"opacity", ""},
+}
+
+// Text style properties used in the Tailwind theme and the key of their text style token, see figma.TextStyleProperty.
+var tailwindTextProperties = map[string]struct {
+	Category string
+	Key      string
+}{
+	"font-family": {"fontFamily", "family"},
+	"font-size":   {"fontSize", "size"},
+	"line-height": {"lineHeight", "line-height"},
+}
+
+type tailwindCategory struct {
+	Name      string
+	Namespace string
+	Values    []fg.TokenValue // Name is the theme key, Value references the token custom property
+}
+
+type tailwindData struct {
+	Categories []tailwindCategory
+	Inline     []fg.TokenValue // v4 theme variables, Name without the leading --
+	Static     []fg.TokenValue
+}
+
+// TailwindConfigEmitter writes a theme extension for tailwind.config.js or .ts.
+// TailwindThemeEmitter writes a Tailwind v4 @theme block.
+//
+// Only tokens declared in :root are used, their values reference the token custom properties so
+// other themes and modes keep working. Colors go to colors, shadows to boxShadow and text styles to
+// fontFamily, fontSize and lineHeight, through their property custom properties when they have them.
+// Variables are mapped by their scopes, FLOAT variables with all scopes are spacing, and their keys
+// start with the collection name.
+var (
+	TailwindConfigEmitter = tailwindEmitter{Name: "tailwind-config", Template: fg.TailwindConfigTemplate}
+	TailwindThemeEmitter  = tailwindEmitter{Name: "tailwind-theme", Template: fg.TailwindThemeTemplate}
+)
+
+type tailwindEmitter struct {
+	Name     string
+	Template string
+}
+
+func (e tailwindEmitter) Emit(tokens map[string]figma.Token) (string, error) {
+	var out bytes.Buffer
+	tmp := figma.CreateTmpl(e.Name, e.Template)
+	err := tmp.Execute(&out, newTailwindData(tokens))
+	if err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+func newTailwindData(tokens map[string]figma.Token) tailwindData {
+	var data tailwindData
+	values := make(map[string]map[string]string)
+
+	add := func(category string, key string, value string) {
+		if values[category] == nil {
+			values[category] = make(map[string]string)
+		}
+		if _, ok := values[category][key]; !ok {
+			values[category][key] = value
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(tokens)) {
+		token := tokens[key]
+		if token.CssSelector() != ":root" {
+			continue
+		}
+
+		if token.ClassName != "" {
+			name := fg.ToKebabCase(strings.TrimPrefix(token.ClassName, "text__style--"))
+			for _, rule := range strings.Split(token.Value, "|") {
+				property, value, ok := strings.Cut(strings.TrimSuffix(rule, ";"), ":")
+				text, found := tailwindTextProperties[strings.TrimSpace(property)]
+				if !ok || !found {
+					continue
+				}
+
+				// text styles have a custom property for each property, see textStyleTokens
+				value = strings.TrimSpace(value)
+				if propertyToken, ok := tokens[fg.TokenKey(key, text.Key)]; ok && strings.HasPrefix(propertyToken.Variable, "--") {
+					value = "var(" + propertyToken.Variable + ")"
+				}
+				add(text.Category, name, value)
+			}
+			continue
+		}
+
+		// text style properties are used through their text style
+		if id, _, ok := strings.Cut(key, "/"); ok && tokens[id].ClassName != "" {
+			continue
+		}
+
+		if !strings.HasPrefix(token.Variable, "--") {
+			continue
+		}
+
+		// variables with the same name in other collections would share the key
+		name := fg.ToKebabCase(token.Collection + " " + token.Name)
+		for _, category := range tailwindTokenCategories(token) {
+			add(category, name, "var("+token.Variable+")")
+		}
+	}
+
+	for _, category := range tailwindCategories {
+		if len(values[category.Name]) == 0 {
+			continue
+		}
+
+		c := tailwindCategory{Name: category.Name, Namespace: category.Namespace}
+		for _, key := range slices.Sorted(maps.Keys(values[category.Name])) {
+			value := tokenValue(key, values[category.Name][key])
+			c.Values = append(c.Values, value)
+
+			if category.Namespace == "" {
+				continue
+			}
+
+			value.Name = category.Namespace + "-" + key
+			if value.Value == "var(--"+value.Name+")" {
+				// the token variable is the theme variable, it keeps its :root value
+				value.Value = tokens[tailwindTokenKey(tokens, value.Name)].Value
+				data.Static = append(data.Static, value)
+			} else {
+				data.Inline = append(data.Inline, value)
+			}
+		}
+		data.Categories = append(data.Categories, c)
+	}
+
+	return data
+}
+
+func tailwindTokenCategories(token figma.Token) []string {
+	switch token.Type {
+	case "color":
+		return []string{"colors"}
+	case "shadow":
+		return []string{"boxShadow"}
+	case "string":
+		if slices.Contains(token.Scopes, fg.VariableScopeFontFamily) {
+			return []string{"fontFamily"}
+		}
+	case "dimension", "number", "fontWeight":
+		if len(token.Scopes) == 0 {
+			return []string{"spacing"}
+		}
+
+		var categories []string
+		for _, scope := range token.Scopes {
+			var category string
+			switch scope {
+			case fg.VariableScopeAllScopes, fg.VariableScopeGap, fg.VariableScopeWidthHeight:
+				category = "spacing"
+			case fg.VariableScopeCornerRadius:
+				category = "borderRadius"
+			case fg.VariableScopeFontSize:
+				category = "fontSize"
+			case fg.VariableScopeFontWeight:
+				category = "fontWeight"
+			case fg.VariableScopeLineHeight:
+				category = "lineHeight"
+			case fg.VariableScopeOpacity:
+				category = "opacity"
+			}
+			if category != "" && !slices.Contains(categories, category) {
+				categories = append(categories, category)
+			}
+		}
+		return categories
+	}
+	return nil
+}
+
+// tailwindTokenKey finds the :root token of a custom property name.
+func tailwindTokenKey(tokens map[string]figma.Token, name string) string {
+	for key, token := range tokens {
+		if token.Variable == "--"+name && token.CssSelector() == ":root" {
+			return key
+		}
+	}
+	return ""
+}
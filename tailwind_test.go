@@ -0,0 +1,127 @@
+package figo
+
+import (
+	"testing"
+
+	"github.com/vpaulo/figo/figma"
+)
+
+var tailwindTokens = map[string]figma.Token{
+	"V:1/1:0": {Name: "Brand/Primary", Variable: "--theme-brand-primary", Value: "rgba(255,0,0,1)", Selector: ":root", Type: "color"},
+	"V:1/1:1": {Name: "Brand/Primary", Variable: "--theme-brand-primary", Value: "rgba(128,0,0,1)", Selector: `[data-theme="dark"]`, Type: "color"},
+	"V:2/2:0": {Name: "Brand", Variable: "--color-brand", Value: "rgba(0,0,255,1)", Selector: ":root", Type: "color"},
+	"V:3/3:0": {Name: "Small", Variable: "--space-small", Value: "4px", Selector: ":root", Type: "dimension", Scopes: []figma.VariableScope{figma.VariableScopeGap, figma.VariableScopeWidthHeight}},
+	"V:4/3:0": {Name: "Rounded", Variable: "--space-rounded", Value: "8px", Selector: ":root", Type: "dimension", Scopes: []figma.VariableScope{figma.VariableScopeCornerRadius}},
+	"V:5/3:0": {Name: "Faded", Variable: "--space-faded", Value: "0.5px", Selector: ":root", Type: "dimension", Scopes: []figma.VariableScope{figma.VariableScopeOpacity}},
+	"S:1":     {Name: "Elevation/Low", Variable: "--elevation-low", Value: "0px 1px 2px 0px rgba(0,0,0,0.25)", Theme: ":root", Type: "shadow"},
+	"S:2":     {Name: "Heading", Value: "font-family: Inter;|font-size: 32px;|font-weight: 700;|line-height: 40px;", ClassName: "text__style--heading", Type: "typography"},
+}
+
+func TestTailwindConfigEmitter(t *testing.T) {
+	f := Figma{}
+	ans, err := f.GenerateTokens(tailwindTokens, TailwindConfigEmitter)
+
+	want := `export default {
+	theme: {
+		extend: {
+			colors: {
+				"brand": "var(--color-brand)",
+				"brand-primary": "var(--theme-brand-primary)",
+			},
+			spacing: {
+				"small": "var(--space-small)",
+			},
+			borderRadius: {
+				"rounded": "var(--space-rounded)",
+			},
+			fontFamily: {
+				"heading": "Inter",
+			},
+			fontSize: {
+				"heading": "32px",
+			},
+			lineHeight: {
+				"heading": "40px",
+			},
+			boxShadow: {
+				"elevation-low": "var(--elevation-low)",
+			},
+			opacity: {
+				"faded": "var(--space-faded)",
+			},
+		},
+	},
+};
+`
+
+	if err != nil || ans != want {
+		t.Errorf("TailwindConfigEmitter = %v, %v; want %v", ans, err, want)
+	}
+}
+
+func TestTailwindThemeEmitter(t *testing.T) {
+	f := Figma{}
+	ans, err := f.GenerateTokens(tailwindTokens, TailwindThemeEmitter)
+
+	want := `@theme inline {
+	--color-brand-primary: var(--theme-brand-primary);
+	--spacing-small: var(--space-small);
+	--radius-rounded: var(--space-rounded);
+	--font-heading: Inter;
+	--text-heading: 32px;
+	--leading-heading: 40px;
+	--shadow-elevation-low: var(--elevation-low);
+}
+
+@theme {
+	--color-brand: rgba(0,0,255,1);
+}
+`
+
+	if err != nil || ans != want {
+		t.Errorf("TailwindThemeEmitter = %v, %v; want %v", ans, err, want)
+	}
+}
+
+func TestTailwindCollections(t *testing.T) {
+	tokens := map[string]figma.Token{
+		"V:1/1:0": {Name: "Primary", Variable: "--brand-primary", Value: "red", Selector: ":root", Type: "color", Collection: "Brand"},
+		"V:2/2:0": {Name: "Primary", Variable: "--status-primary", Value: "blue", Selector: ":root", Type: "color", Collection: "Status"},
+	}
+
+	data := newTailwindData(tokens)
+	if len(data.Categories) != 1 || len(data.Categories[0].Values) != 2 {
+		t.Fatalf("newTailwindData = %+v; want both colors", data)
+	}
+
+	for i, want := range []string{"brand-primary", "status-primary"} {
+		if ans := data.Categories[0].Values[i].Name; ans != want {
+			t.Errorf("%+v = %v; want %v", i, ans, want)
+		}
+	}
+}
+
+func TestTailwindTextStyleProperties(t *testing.T) {
+	tokens := map[string]figma.Token{
+		"S:1":        {Name: "Heading", Value: "font-family: Inter;|font-size: 32px;|line-height: 40px;", ClassName: "text__style--heading", Type: "typography"},
+		"S:1/family": {Name: "Heading/family", Variable: "--text-heading-family", Value: "Inter", Type: "fontFamily"},
+		"S:1/size":   {Name: "Heading/size", Variable: "--text-heading-size", Value: "32px", Type: "dimension"},
+	}
+
+	want := map[string]string{
+		"fontFamily": "var(--text-heading-family)",
+		"fontSize":   "var(--text-heading-size)",
+		"lineHeight": "40px",
+	}
+
+	data := newTailwindData(tokens)
+	if len(data.Categories) != len(want) {
+		t.Errorf("newTailwindData = %+v; want %v categories", data, len(want))
+	}
+
+	for _, category := range data.Categories {
+		if len(category.Values) != 1 || category.Values[0].Name != "heading" || category.Values[0].Value != want[category.Name] {
+			t.Errorf("%+v = %+v; want heading %v", category.Name, category.Values, want[category.Name])
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package figo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vpaulo/figo/figma"
+	fg "github.com/vpaulo/figo/figma"
+)
+
+// ThemeSelector returns the CSS selector the variables of a collection mode are declared under.
+// Selectors starting with @media wrap a :root block.
+type ThemeSelector func(collection figma.VariableCollection, mode figma.Modes) string
+
+// DataThemeSelector declares the default mode in :root and the other modes in an attribute named
+// after the collection, e.g. [data-color="dark"] and [data-density="compact"], so a page can use
+// a mode of every collection at once. Collections without a name use [data-theme="mode"].
+func DataThemeSelector(collection figma.VariableCollection, mode figma.Modes) string {
+	if mode.ModeId == collection.DefaultModeId {
+		return ":root"
+	}
+
+	attribute := fg.ToKebabCase(collection.Name)
+	if attribute == "" {
+		attribute = "theme"
+	}
+	return fmt.Sprintf("[data-%v=\"%v\"]", attribute, fg.ToKebabCase(mode.Name))
+}
+
+// ClassThemeSelector declares the default mode in :root and the other modes in a class named after the mode.
+func ClassThemeSelector(collection figma.VariableCollection, mode figma.Modes) string {
+	if mode.ModeId == collection.DefaultModeId {
+		return ":root"
+	}
+	return "." + fg.ToKebabCase(mode.Name)
+}
+
+// MediaThemeSelector declares the modes named in queries, case insensitive, in a media query,
+// e.g. {"Dark": "(prefers-color-scheme: dark)"}, and the other modes like DataThemeSelector.
+func MediaThemeSelector(queries map[string]string) ThemeSelector {
+	return func(collection figma.VariableCollection, mode figma.Modes) string {
+		for name, query := range queries {
+			if mode.ModeId != collection.DefaultModeId && strings.EqualFold(name, mode.Name) {
+				return "@media " + query
+			}
+		}
+		return DataThemeSelector(collection, mode)
+	}
+}
+
+// ColorScheme changes how variable modes named light and dark are output, the other modes
+// keep their ThemeSelector.
+type ColorScheme struct {
+	Selector  string // Selector of dark modes, e.g. `[data-color="dark"]` or `:root.dark`, the ThemeSelector one when empty
+	Media     bool   // Dark modes are also declared inside @media (prefers-color-scheme: dark)
+	LightDark bool   // Colors with a light and a dark value are declared once in :root with light-dark()
+}
+
+const colorSchemeMedia = "@media (prefers-color-scheme: dark)"
+
+func (f *Figma) themeSelector() ThemeSelector {
+	if f.ThemeSelector != nil {
+		return f.ThemeSelector
+	}
+	return DataThemeSelector
+}
+
+// variableSelectors maps every mode ID to its selector.
+func (f *Figma) variableSelectors(collections map[string]fg.VariableCollection) map[string]string {
+	selectors := make(map[string]string)
+	selector := f.themeSelector()
+
+	for _, c := range collections {
+		for _, mode := range c.Modes {
+			selectors[mode.ModeId] = selector(c, mode)
+		}
+	}
+
+	return selectors
+}
+
+// variableModes maps every mode ID to its name.
+func variableModes(collections map[string]fg.VariableCollection) map[string]string {
+	modes := make(map[string]string)
+
+	for _, c := range collections {
+		for _, mode := range c.Modes {
+			modes[mode.ModeId] = mode.Name
+		}
+	}
+
+	return modes
+}
+
+// Modes are recognised by name, e.g. "Dark", "Dark mode" or "Light theme".
+func colorScheme(mode string) string {
+	mode = strings.ToLower(mode)
+	switch {
+	case strings.Contains(mode, "dark"):
+		return "dark"
+	case strings.Contains(mode, "light"):
+		return "light"
+	}
+	return ""
+}
+
+// applyColorScheme moves the tokens of light and dark modes to the ColorScheme selectors.
+func (f *Figma) applyColorScheme(tokens map[string]fg.Token, meta fg.Meta) {
+	scheme := f.ColorScheme
+	if scheme == nil {
+		return
+	}
+
+	for _, v := range meta.Variables {
+		collection := meta.VariableCollections[v.VariableCollectionId]
+
+		var light, dark string
+		for _, mode := range collection.Modes {
+			switch colorScheme(mode.Name) {
+			case "light":
+				light = fg.TokenKey(v.ID, mode.ModeId)
+			case "dark":
+				dark = fg.TokenKey(v.ID, mode.ModeId)
+			}
+		}
+
+		lightToken, hasLight := tokens[light]
+		darkToken, hasDark := tokens[dark]
+		if !hasDark {
+			continue
+		}
+
+		if scheme.LightDark && hasLight && v.ResolvedType == fg.ResolvedTypeColor {
+			lightToken.Value = fmt.Sprintf("light-dark(%v, %v)", lightToken.Value, darkToken.Value)
+			lightToken.Selector = ":root"
+			tokens[light] = lightToken
+			delete(tokens, dark)
+			continue
+		}
+
+		if scheme.Selector != "" {
+			darkToken.Selector = scheme.Selector
+			tokens[dark] = darkToken
+		}
+
+		if scheme.Media {
+			darkToken.Selector = colorSchemeMedia
+			tokens[dark+"/"+colorSchemeMedia] = darkToken
+		}
+	}
+
+	// light-dark() follows the color-scheme property, :root already follows the system preference
+	if scheme.LightDark {
+		tokens["color-scheme"] = fg.Token{Variable: "color-scheme", Value: "light dark", Selector: ":root"}
+		if scheme.Selector != "" {
+			tokens["color-scheme/dark"] = fg.Token{Variable: "color-scheme", Value: "dark", Selector: scheme.Selector}
+		}
+	}
+}
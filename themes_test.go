@@ -0,0 +1,175 @@
+package figo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/vpaulo/figo/figma"
+)
+
+var themedVariables = []byte(`{"meta": {
+	"variableCollections": {
+		"C:1": {"id": "C:1", "name": "Color", "defaultModeId": "1:0", "modes": [{"modeId": "1:0", "name": "Light"}, {"modeId": "1:1", "name": "Dark"}]},
+		"C:2": {"id": "C:2", "name": "Density", "defaultModeId": "2:0", "modes": [{"modeId": "2:0", "name": "Comfortable"}, {"modeId": "2:1", "name": "Compact"}]}
+	},
+	"variables": {
+		"V:1": {"id": "V:1", "name": "Surface", "variableCollectionId": "C:1", "resolvedType": "COLOR", "valuesByMode": {
+			"1:0": {"r": 1, "g": 1, "b": 1, "a": 1},
+			"1:1": {"r": 0, "g": 0, "b": 0, "a": 1}
+		}},
+		"V:2": {"id": "V:2", "name": "Gap", "variableCollectionId": "C:2", "resolvedType": "FLOAT", "valuesByMode": {"2:0": 16, "2:1": 8}}
+	}
+}}`)
+
+func TestParseVariablesModes(t *testing.T) {
+	var variables figma.Variables
+	if err := json.Unmarshal(themedVariables, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	tests := []struct {
+		selector ThemeSelector
+		want     map[string]string
+	}{
+		{nil, map[string]string{
+			"V:1/1:0": ":root",
+			"V:1/1:1": `[data-color="dark"]`,
+			"V:2/2:0": ":root",
+			"V:2/2:1": `[data-density="compact"]`,
+		}},
+		{ClassThemeSelector, map[string]string{
+			"V:1/1:1": ".dark",
+			"V:2/2:1": ".compact",
+		}},
+		{MediaThemeSelector(map[string]string{"dark": "(prefers-color-scheme: dark)"}), map[string]string{
+			"V:1/1:1": "@media (prefers-color-scheme: dark)",
+			"V:2/2:1": `[data-density="compact"]`,
+		}},
+	}
+
+	for _, test := range tests {
+		f := Figma{ThemeSelector: test.selector}
+		tokens := f.ParseVariables(variables)
+
+		if len(tokens) != 4 {
+			t.Errorf("ParseVariables = %+v; want a token per variable and mode", tokens)
+		}
+
+		for key, want := range test.want {
+			if ans := tokens[key].CssSelector(); ans != want {
+				t.Errorf("%+v = %v; want %v", key, ans, want)
+			}
+		}
+	}
+}
+
+func TestGenerateTokensCSS(t *testing.T) {
+	tokens := map[string]figma.Token{
+		"V:1/1:0": {Variable: "--color-surface", Value: "white", Selector: ":root"},
+		"V:1/1:1": {Variable: "--color-surface", Value: "black", Selector: "@media (prefers-color-scheme: dark)"},
+		"V:2/2:1": {Variable: "--density-gap", Value: "8px", Selector: `[data-theme="compact"]`},
+		"S:1":     {Variable: "--brand", Value: "red", Theme: ":root"},
+		"S:2":     {Variable: "--brand", Value: "blue", Theme: "dark-theme"},
+		"S:3":     {Value: "font-size: 16px;|font-weight: 700;", ClassName: "text__style--body"},
+	}
+
+	f := Figma{}
+	ans, err := f.GenerateTokensCSS(tokens)
+	if err != nil {
+		t.Fatalf("GenerateTokensCSS error: %v", err)
+	}
+
+	want := `
+:root {
+ 	--brand: red;
+ 	--color-surface: white;
+}
+
+.dark-theme {
+ 	--brand: blue;
+}
+
+.text__style--body {
+ 	font-size: 16px;
+ 	font-weight: 700;
+}
+
+[data-theme="compact"] {
+ 	--density-gap: 8px;
+}
+
+@media (prefers-color-scheme: dark) {
+:root {
+ 	--color-surface: black;
+}
+}
+`
+
+	if ans != want {
+		t.Errorf("GenerateTokensCSS = %v; want %v", ans, want)
+	}
+}
+
+func TestGenerateTokensCSSOrder(t *testing.T) {
+	var variables figma.Variables
+	if err := json.Unmarshal(themedVariables, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	// class selectors have the specificity of :root, they only apply when declared after it
+	f := Figma{ThemeSelector: ClassThemeSelector}
+	ans, err := f.GenerateTokensCSS(f.ParseVariables(variables))
+	if err != nil {
+		t.Fatalf("GenerateTokensCSS error: %v", err)
+	}
+
+	root := strings.Index(ans, ":root {")
+	compact := strings.Index(ans, ".compact {")
+	dark := strings.Index(ans, ".dark {")
+	if root < 0 || root > compact || root > dark {
+		t.Errorf("GenerateTokensCSS = %v; want :root before the mode selectors", ans)
+	}
+}
+
+func TestParseVariablesColorScheme(t *testing.T) {
+	var variables figma.Variables
+	if err := json.Unmarshal(themedVariables, &variables); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	f := Figma{ColorScheme: &ColorScheme{Selector: ":root.dark", Media: true}}
+	tokens := f.ParseVariables(variables)
+
+	want := map[string]string{
+		"V:1/1:0":                     ":root",
+		"V:1/1:1":                     ":root.dark",
+		"V:1/1:1/" + colorSchemeMedia: colorSchemeMedia,
+		"V:2/2:1":                     `[data-density="compact"]`,
+	}
+
+	for key, selector := range want {
+		if ans := tokens[key].CssSelector(); ans != selector {
+			t.Errorf("%+v = %v; want %v", key, ans, selector)
+		}
+	}
+
+	f = Figma{ColorScheme: &ColorScheme{Selector: `[data-theme="dark"]`, LightDark: true}}
+	tokens = f.ParseVariables(variables)
+
+	if ans := tokens["V:1/1:0"].Value; ans != "light-dark(rgba(255,255,255,1), rgba(0,0,0,1))" {
+		t.Errorf("V:1/1:0 = %v; want light-dark()", ans)
+	}
+
+	if _, ok := tokens["V:1/1:1"]; ok {
+		t.Errorf("V:1/1:1 = %+v; want merged into light-dark()", tokens["V:1/1:1"])
+	}
+
+	if ans := tokens["color-scheme"]; ans.Value != "light dark" || ans.CssSelector() != ":root" {
+		t.Errorf("color-scheme = %+v; want light dark in :root", ans)
+	}
+
+	if ans := tokens["color-scheme/dark"]; ans.Value != "dark" || ans.CssSelector() != `[data-theme="dark"]` {
+		t.Errorf("color-scheme/dark = %+v; want dark in the dark selector", ans)
+	}
+}
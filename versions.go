@@ -0,0 +1,59 @@
+package figo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/vpaulo/figo/figma"
+	fg "github.com/vpaulo/figo/figma"
+)
+
+func (figma *Figma) getVersionsUri() (string, error) {
+	component_url := figma.baseUrl() + `/v1/files/{{.FILE_KEY}}/versions`
+	t := fg.CreateTmpl("figma_uri", component_url)
+
+	var result bytes.Buffer
+
+	err := t.Execute(&result, figma)
+	if err != nil {
+		return "", err
+	}
+
+	return result.String(), nil
+}
+
+// GetVersions lists the file versions, newest first, following every page of the response.
+func (f *Figma) GetVersions() (figma.FileVersions, error) {
+	return f.GetVersionsContext(context.Background())
+}
+
+func (f *Figma) GetVersionsContext(ctx context.Context) (figma.FileVersions, error) {
+	var versions figma.FileVersions
+
+	uri, uriError := f.getVersionsUri()
+	if uriError != nil {
+		return versions, uriError
+	}
+
+	for uri != "" {
+		body, httpError := f.get(ctx, uri)
+		if httpError != nil {
+			return versions, httpError
+		}
+
+		var page figma.FileVersions
+		if unmarshallingError := json.Unmarshal(body, &page); unmarshallingError != nil {
+			return versions, unmarshallingError
+		}
+
+		versions.Versions = append(versions.Versions, page.Versions...)
+
+		if page.Pagination.NextPage == uri {
+			break
+		}
+		uri = page.Pagination.NextPage
+	}
+
+	return versions, nil
+}
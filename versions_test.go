@@ -0,0 +1,52 @@
+package figo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetVersions(t *testing.T) {
+	var url string
+	url = testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/files/KEY/versions" {
+			t.Errorf("unexpected request %v", r.URL)
+		}
+
+		if r.URL.Query().Get("before") == "" {
+			w.Write([]byte(`{"versions":[{"id":"2","created_at":"2026-01-02T00:00:00Z","label":"","user":{"id":"u1","handle":"Designer"}}],"pagination":{"next_page":"` + url + `/v1/files/KEY/versions?before=2"}}`))
+			return
+		}
+
+		w.Write([]byte(`{"versions":[{"id":"1","created_at":"2026-01-01T00:00:00Z","label":"v1.0","description":"Approved"}],"pagination":{}}`))
+	})
+
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url}
+	versions, err := f.GetVersions()
+	if err != nil {
+		t.Fatalf("GetVersions error: %v", err)
+	}
+
+	if len(versions.Versions) != 2 || versions.Versions[0].User.Handle != "Designer" {
+		t.Fatalf("GetVersions = %+v", versions)
+	}
+
+	version, ok := versions.Labelled("v1.0")
+	if !ok || version.ID != "1" || version.Description != "Approved" {
+		t.Errorf("Labelled = %+v, %v", version, ok)
+	}
+}
+
+func TestGetDataVersion(t *testing.T) {
+	url := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/files/KEY" || r.URL.Query().Get("version") != "1" {
+			t.Errorf("unexpected request %v", r.URL)
+		}
+		w.Write([]byte(`{"name":"Design System","version":"1"}`))
+	})
+
+	f := Figma{FILE_KEY: "KEY", BaseUrl: url, Version: "1"}
+	file, err := f.GetData()
+	if err != nil || file.Version != "1" {
+		t.Errorf("GetData = %+v, %v", file, err)
+	}
+}